@@ -206,6 +206,15 @@ func calculateScore(cat *Category, doc *goquery.Document, rawHTML, visibleText,
 	return score
 }
 
+// VisibleText HTML içeriğinin görünen metnini döndürür (benzerlik analizi vb. için)
+func VisibleText(htmlContent string) string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+	if err != nil {
+		return ""
+	}
+	return extractVisibleText(doc)
+}
+
 // extractVisibleText sadece sayfanın görünen metnini çeker
 func extractVisibleText(doc *goquery.Document) string {
 	// Bodynin bir kopyasını al
@@ -7,6 +7,13 @@ import (
 	"strings"
 )
 
+// reservedFiles hedef listesi olmayan, programın kendi kullandığı yapılandırma dosyaları
+var reservedFiles = map[string]bool{
+	"rules.yaml":          true,
+	"leak_templates.yaml": true,
+	"settings.yaml":       true,
+}
+
 // ListYamlFiles config dizinindeki taranabilecek .yaml dosyalarını listeler
 func ListYamlFiles() ([]string, error) {
 	var files []string
@@ -18,10 +25,10 @@ func ListYamlFiles() ([]string, error) {
 	}
 
 	for _, entry := range entries {
-		// .yaml/.yml uzantılı olmalı ve rules.yaml'ı dahil etmedim
+		// .yaml/.yml uzantılı olmalı ve rules.yaml gibi ayar dosyalarını dahil etmedim
 		if !entry.IsDir() &&
 			(strings.HasSuffix(entry.Name(), ".yaml") || strings.HasSuffix(entry.Name(), ".yml")) &&
-			entry.Name() != "rules.yaml" {
+			!reservedFiles[entry.Name()] {
 
 			// Tam yolunu ekle: config/targets.yaml gibi
 			files = append(files, configDir+"/"+entry.Name()) // Basit path birleştirme
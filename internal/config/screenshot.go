@@ -0,0 +1,271 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Sayfanın hazır sayılması için bekleme stratejileri
+const (
+	WaitNetworkIdle = "network_idle" // Ağ trafiği durulana kadar (Chrome'un networkIdle olayı)
+	WaitSelector    = "selector"     // Verilen CSS seçicisi görünür olana kadar
+	WaitDelay       = "delay"        // Sabit süre
+)
+
+// ViewportPresets isimle seçilebilen pencere boyutları
+var ViewportPresets = map[string][2]int{
+	"desktop": {1920, 1080},
+	"laptop":  {1366, 768},
+	"classic": {1280, 1024},
+	"tablet":  {768, 1024},
+	"mobile":  {390, 844},
+}
+
+// ScreenshotOptions tek bir hedef için tarayıcı bekleme ve ekran görüntüsü seçenekleri
+type ScreenshotOptions struct {
+	Wait        string        `yaml:"wait"`         // network_idle | selector | delay
+	Selector    string        `yaml:"selector"`     // wait: selector için CSS seçici
+	Delay       time.Duration `yaml:"delay"`        // delay stratejisinde bekleme, diğerlerinde ek bekleme
+	WaitTimeout time.Duration `yaml:"wait_timeout"` // network_idle/selector için en fazla bekleme (sonra yine de çekilir)
+	Timeout     time.Duration `yaml:"timeout"`      // Sekmenin toplam ömrü
+	Viewport    string        `yaml:"viewport"`     // Hazır isim (desktop, mobile...) veya "1280x1024"
+	FullPage    bool          `yaml:"full_page"`    // false: sadece görünen alan
+	Format      string        `yaml:"format"`       // png | jpeg
+	Quality     int           `yaml:"quality"`      // jpeg kalitesi (1-100)
+	PDF         bool          `yaml:"pdf"`          // Aynı oturumdan yazdırma (print-to-PDF) çıktısı
+	MHTML       bool          `yaml:"mhtml"`        // Aynı oturumdan tek dosyalık MHTML arşivi
+	HAR         bool          `yaml:"har"`          // Render sırasındaki tüm alt isteklerin HAR 1.2 kaydı
+	Policy      BrowserPolicy `yaml:"policy"`       // JavaScript ve kaynak yükleme kısıtlamaları
+}
+
+// BrowserPolicy riskli siteleri en az maruziyetle açmak için sekme kısıtlamaları
+type BrowserPolicy struct {
+	JavaScript     bool `yaml:"javascript"`      // false: sayfanın scriptleri çalıştırılmaz
+	BlockImages    bool `yaml:"block_images"`    // Resimler yüklenmez
+	BlockMedia     bool `yaml:"block_media"`     // Video ve ses yüklenmez
+	BlockFonts     bool `yaml:"block_fonts"`     // Web fontları yüklenmez
+	BlockDownloads bool `yaml:"block_downloads"` // Sayfanın başlattığı dosya indirmeleri reddedilir
+	BlockCanvas    bool `yaml:"block_canvas"`    // WebGL ve canvas okuma (parmak izi) engellenir
+	BlockClearnet  bool `yaml:"block_clearnet"`  // .onion dışındaki tüm bağlantılar proxy katmanında reddedilir
+}
+
+// Intercepts istek yakalama (Fetch) gerektiren bir kısıtlama olup olmadığını söyler
+func (p BrowserPolicy) Intercepts() bool {
+	return p.BlockImages || p.BlockMedia || p.BlockFonts
+}
+
+// ScreenshotSettings varsayılan seçenekler ve hedefe özel geçersiz kılmalar
+type ScreenshotSettings struct {
+	ScreenshotOptions `yaml:",inline"`
+	Overrides         []ScreenshotOverride `yaml:"overrides"`
+}
+
+// ScreenshotOverride hedefe veya kategoriye özel ayarlar. "match" URL içinde geçen,
+// "category" (tek değer veya liste) ham sayfası o kategoriye sınıflanan hedeflere uygulanır;
+// ikisi birlikte yazılırsa ikisi de uymalıdır. Sadece yazılan (nil olmayan) alanlar değişir.
+type ScreenshotOverride struct {
+	Match       string          `yaml:"match"`
+	Category    StringList      `yaml:"category"`
+	Wait        *string         `yaml:"wait"`
+	Selector    *string         `yaml:"selector"`
+	Delay       *time.Duration  `yaml:"delay"`
+	WaitTimeout *time.Duration  `yaml:"wait_timeout"`
+	Timeout     *time.Duration  `yaml:"timeout"`
+	Viewport    *string         `yaml:"viewport"`
+	FullPage    *bool           `yaml:"full_page"`
+	Format      *string         `yaml:"format"`
+	Quality     *int            `yaml:"quality"`
+	PDF         *bool           `yaml:"pdf"`
+	MHTML       *bool           `yaml:"mhtml"`
+	HAR         *bool           `yaml:"har"`
+	Policy      *PolicyOverride `yaml:"policy"`
+}
+
+// PolicyOverride BrowserPolicy'nin sadece yazılan alanlarını değiştiren karşılığı
+type PolicyOverride struct {
+	JavaScript     *bool `yaml:"javascript"`
+	BlockImages    *bool `yaml:"block_images"`
+	BlockMedia     *bool `yaml:"block_media"`
+	BlockFonts     *bool `yaml:"block_fonts"`
+	BlockDownloads *bool `yaml:"block_downloads"`
+	BlockCanvas    *bool `yaml:"block_canvas"`
+	BlockClearnet  *bool `yaml:"block_clearnet"`
+}
+
+// StringList YAML'da tek değer veya liste olarak yazılabilen metin listesi
+type StringList []string
+
+// UnmarshalYAML "category: drugs" ve "category: [drugs, weapons]" yazımlarını kabul eder
+func (l *StringList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		var v string
+		if err := node.Decode(&v); err != nil {
+			return err
+		}
+		*l = nil
+		if v = strings.TrimSpace(v); v != "" {
+			*l = StringList{v}
+		}
+		return nil
+	}
+	var list []string
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+	*l = nil
+	for _, v := range list {
+		if v = strings.TrimSpace(v); v != "" {
+			*l = append(*l, v)
+		}
+	}
+	return nil
+}
+
+// matches override'ın hedefe uyup uymadığını söyler
+func (o ScreenshotOverride) matches(url, category string) bool {
+	if o.Match == "" && len(o.Category) == 0 {
+		return false
+	}
+	if o.Match != "" && !strings.Contains(url, o.Match) {
+		return false
+	}
+	if len(o.Category) > 0 && !containsFold(o.Category, category) {
+		return false
+	}
+	return true
+}
+
+// apply yazılan alanları seçeneklerin üzerine kopyalar
+func (o ScreenshotOverride) apply(opts *ScreenshotOptions) {
+	set(&opts.Wait, o.Wait)
+	set(&opts.Selector, o.Selector)
+	set(&opts.Delay, o.Delay)
+	set(&opts.WaitTimeout, o.WaitTimeout)
+	set(&opts.Timeout, o.Timeout)
+	set(&opts.Viewport, o.Viewport)
+	set(&opts.FullPage, o.FullPage)
+	set(&opts.Format, o.Format)
+	set(&opts.Quality, o.Quality)
+	set(&opts.PDF, o.PDF)
+	set(&opts.MHTML, o.MHTML)
+	set(&opts.HAR, o.HAR)
+	if p := o.Policy; p != nil {
+		set(&opts.Policy.JavaScript, p.JavaScript)
+		set(&opts.Policy.BlockImages, p.BlockImages)
+		set(&opts.Policy.BlockMedia, p.BlockMedia)
+		set(&opts.Policy.BlockFonts, p.BlockFonts)
+		set(&opts.Policy.BlockDownloads, p.BlockDownloads)
+		set(&opts.Policy.BlockCanvas, p.BlockCanvas)
+		set(&opts.Policy.BlockClearnet, p.BlockClearnet)
+	}
+}
+
+func set[T any](dst *T, src *T) {
+	if src != nil {
+		*dst = *src
+	}
+}
+
+// Validate hatalı ayarları (hedef seçicisi olmayan override, bilinmeyen bekleme/format) listeler
+func (s ScreenshotSettings) Validate() error {
+	var problems []string
+	if !validWait(s.Wait) {
+		problems = append(problems, fmt.Sprintf("screenshot: bilinmeyen wait değeri %q", s.Wait))
+	}
+	if !validFormat(s.Format) {
+		problems = append(problems, fmt.Sprintf("screenshot: bilinmeyen format %q", s.Format))
+	}
+	for i, o := range s.Overrides {
+		prefix := fmt.Sprintf("screenshot.overrides[%d]", i)
+		if o.Match == "" && len(o.Category) == 0 {
+			problems = append(problems, prefix+": match veya category yazılmalı")
+		}
+		if o.Wait != nil && !validWait(*o.Wait) {
+			problems = append(problems, fmt.Sprintf("%s: bilinmeyen wait değeri %q", prefix, *o.Wait))
+		}
+		if o.Format != nil && !validFormat(*o.Format) {
+			problems = append(problems, fmt.Sprintf("%s: bilinmeyen format %q", prefix, *o.Format))
+		}
+	}
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func validWait(w string) bool {
+	return w == WaitNetworkIdle || w == WaitSelector || w == WaitDelay
+}
+
+func validFormat(f string) bool {
+	switch strings.ToLower(f) {
+	case "png", "jpeg", "jpg":
+		return true
+	}
+	return false
+}
+
+// DefaultScreenshotOptions ayar dosyasında belirtilmeyen alanlar için değerler
+func DefaultScreenshotOptions() ScreenshotOptions {
+	return ScreenshotOptions{
+		Wait:        WaitNetworkIdle,
+		Delay:       500 * time.Millisecond,
+		WaitTimeout: 15 * time.Second,
+		Timeout:     45 * time.Second,
+		Viewport:    "classic",
+		FullPage:    true,
+		Format:      "png",
+		Quality:     90,
+		HAR:         true,
+		Policy:      BrowserPolicy{JavaScript: true},
+	}
+}
+
+// For URL'e ve kategoriye uyan geçersiz kılmaları varsayılanların üzerine sırayla uygular
+func (s ScreenshotSettings) For(url, category string) ScreenshotOptions {
+	opts := s.ScreenshotOptions
+	for _, o := range s.Overrides {
+		if o.matches(url, category) {
+			o.apply(&opts)
+		}
+	}
+	return opts
+}
+
+// HasCategoryOverrides kategoriye göre seçilen bir override olup olmadığını söyler
+// (yoksa tarayıcı adımından önce ön sınıflandırma yapılmaz)
+func (s ScreenshotSettings) HasCategoryOverrides() bool {
+	for _, o := range s.Overrides {
+		if len(o.Category) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(list []string, value string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// ViewportSize görünüm alanı adını veya "GxY" değerini piksele çevirir, mobil ön ayarlar için true döner
+func (o ScreenshotOptions) ViewportSize() (int, int, bool) {
+	if size, ok := ViewportPresets[strings.ToLower(o.Viewport)]; ok {
+		return size[0], size[1], strings.EqualFold(o.Viewport, "mobile")
+	}
+
+	var w, h int
+	if n, _ := fmt.Sscanf(strings.ToLower(o.Viewport), "%dx%d", &w, &h); n == 2 && w > 0 && h > 0 {
+		return w, h, false
+	}
+	size := ViewportPresets["classic"]
+	return size[0], size[1], false
+}
@@ -0,0 +1,172 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Settings config/settings.yaml içindeki program ayarları
+type Settings struct {
+	Store        StoreSettings        `yaml:"store"`
+	Retention    RetentionSettings    `yaml:"retention"`
+	VisualDiff   VisualDiffSettings   `yaml:"visual_diff"`
+	VisualHash   VisualHashSettings   `yaml:"visual_hash"`
+	ContactSheet ContactSheetSettings `yaml:"contact_sheet"`
+	Export       ExportSettings       `yaml:"export"`
+	Browser      BrowserSettings      `yaml:"browser"`
+	Screenshot   ScreenshotSettings   `yaml:"screenshot"`
+	Gate         GateSettings         `yaml:"gate"`
+}
+
+// StoreSettings taramalar arası kalıcı SQLite veritabanı ayarları
+type StoreSettings struct {
+	Enabled bool   `yaml:"enabled"`
+	Path    string `yaml:"path"`
+}
+
+// RetentionSettings zaman damgalı çalıştırma klasörlerinin saklama politikası (0 = sınırsız)
+type RetentionSettings struct {
+	KeepLast   int `yaml:"keep_last"`
+	MaxAgeDays int `yaml:"max_age_days"`
+}
+
+// VisualDiffSettings ekran görüntülerinin önceki çalıştırmayla karşılaştırılması
+type VisualDiffSettings struct {
+	Enabled   bool    `yaml:"enabled"`
+	Threshold float64 `yaml:"threshold"` // Değişen alan yüzdesi bu değeri aşarsa hedef listelenir
+}
+
+// VisualHashSettings ekran görüntüsü algısal hash'leri ile görsel kümeleme
+type VisualHashSettings struct {
+	Enabled     bool `yaml:"enabled"`
+	MaxDistance int  `yaml:"max_distance"` // 64 bitlik hash'ler arasında izin verilen en fazla farklı bit
+}
+
+// ContactSheetSettings küçük resimler ve tüm hedefleri tek bakışta gösteren kontak sayfaları
+type ContactSheetSettings struct {
+	Enabled     bool `yaml:"enabled"`
+	ThumbWidth  int  `yaml:"thumb_width"`
+	ThumbHeight int  `yaml:"thumb_height"` // Uzun sayfaların sadece üst kısmı alınır
+	Columns     int  `yaml:"columns"`
+	PerSheet    int  `yaml:"per_sheet"` // Bir kontak sayfasındaki en fazla hedef; fazlası yeni sayfaya geçer
+}
+
+// Validate açıkken küçük resim ölçülerinin ve sütun sayısının pozitif olduğunu kontrol eder
+// (0 genişlik tam boy görüntüyü küçük resim diye yazar, 0 sütun sessizce mozaik üretmez)
+func (c ContactSheetSettings) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	var problems []string
+	for _, f := range []struct {
+		name  string
+		value int
+	}{{"thumb_width", c.ThumbWidth}, {"thumb_height", c.ThumbHeight}, {"columns", c.Columns}} {
+		if f.value <= 0 {
+			problems = append(problems, fmt.Sprintf("contact_sheet.%s pozitif olmalı (%d)", f.name, f.value))
+		}
+	}
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// ExportSettings dışa aktarım seçenekleri
+type ExportSettings struct {
+	XLSX          bool `yaml:"xlsx"`            // scan_summary.csv'nin yanında scan_summary.xlsx üret
+	MISPPerTarget bool `yaml:"misp_per_target"` // Tek event yerine hedef başına MISP event'i
+}
+
+// BrowserSettings ekran görüntüsü tarayıcısı (boş path = otomatik bulma)
+type BrowserSettings struct {
+	Path  string   `yaml:"path"`
+	Flags []string `yaml:"flags"` // "--isim=değer" veya "--isim" biçiminde ek başlatma bayrakları
+	// RenderDOM true ise sınıflandırma, link çıkarma ve kaydedilen HTML için
+	// JavaScript çalıştıktan sonraki DOM kullanılır; ham gövde .raw.html olarak saklanır
+	RenderDOM bool `yaml:"render_dom"`
+}
+
+// GateSettings DDoS koruması, bekleme sırası ve captcha geçitleri
+type GateSettings struct {
+	Retry      bool          `yaml:"retry"`       // Bekleme sırası sayfalarında bekleyip tekrar dene
+	MaxRetries int           `yaml:"max_retries"` // Hedef başına en fazla tekrar
+	RetryWait  time.Duration `yaml:"retry_wait"`  // Sayfa bekleme süresi bildirmezse kullanılır
+	MaxWait    time.Duration `yaml:"max_wait"`    // Sayfanın bildirdiği süre bundan uzunsa kısaltılır
+	// Interactive true ise çözülemeyen geçitler için görünür bir tarayıcı açılır;
+	// analist captcha'yı çözünce oturum çerezleriyle taramaya devam edilir
+	Interactive  bool          `yaml:"interactive"`
+	SolveTimeout time.Duration `yaml:"solve_timeout"` // Analistin geçidi çözmesi için en fazla süre
+}
+
+// GlobalSettings yüklenen (veya varsayılan) ayarlar
+var GlobalSettings = DefaultSettings()
+
+// DefaultSettings ayar dosyası yoksa kullanılan değerler
+func DefaultSettings() Settings {
+	return Settings{
+		Store: StoreSettings{
+			Enabled: false,
+			Path:    "data/onionscraper.db",
+		},
+		Retention: RetentionSettings{
+			KeepLast:   10,
+			MaxAgeDays: 0,
+		},
+		Screenshot: ScreenshotSettings{
+			ScreenshotOptions: DefaultScreenshotOptions(),
+		},
+		VisualDiff: VisualDiffSettings{
+			Enabled:   true,
+			Threshold: 5,
+		},
+		VisualHash: VisualHashSettings{
+			Enabled:     true,
+			MaxDistance: 10,
+		},
+		ContactSheet: ContactSheetSettings{
+			Enabled:     true,
+			ThumbWidth:  320,
+			ThumbHeight: 240,
+			Columns:     6,
+			PerSheet:    48,
+		},
+		Gate: GateSettings{
+			Retry:        true,
+			MaxRetries:   3,
+			RetryWait:    10 * time.Second,
+			MaxWait:      60 * time.Second,
+			Interactive:  false,
+			SolveTimeout: 5 * time.Minute,
+		},
+	}
+}
+
+// LoadSettings ayar dosyasını varsayılanların üzerine yükler.
+// Hata durumunda GlobalSettings varsayılan değerlerde kalır; dosya yoksa dönen hata
+// os.ErrNotExist ile eşleşir (diğer hatalar kısıtlamaların sessizce kaybolmaması için ölümcül sayılmalı).
+func LoadSettings(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("ayar dosyası okunamadı: %w", err)
+	}
+
+	settings := DefaultSettings()
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		return fmt.Errorf("YAML parse hatası: %v", err)
+	}
+	if err := settings.Screenshot.Validate(); err != nil {
+		return fmt.Errorf("geçersiz ekran görüntüsü ayarı: %v", err)
+	}
+	if err := settings.ContactSheet.Validate(); err != nil {
+		return fmt.Errorf("geçersiz kontak sayfası ayarı: %v", err)
+	}
+
+	GlobalSettings = settings
+	return nil
+}
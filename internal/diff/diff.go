@@ -0,0 +1,288 @@
+package diff
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"galileoff-OnionScraper/internal/classifier"
+	"galileoff-OnionScraper/internal/scanner"
+	"galileoff-OnionScraper/internal/utils"
+)
+
+// ReportFile karşılaştırma raporunun yeni tarama klasörüne yazıldığı dosya
+const ReportFile = "diff_report.txt"
+
+// Report iki tarama arasındaki farklar
+type Report struct {
+	OldDir  string
+	NewDir  string
+	Up      []string // Önceden erişilemeyen, şimdi erişilen
+	Down    []string // Önceden erişilen, şimdi erişilemeyen
+	Added   []string // Sadece yeni taramada olan hedefler
+	Removed []string // Sadece eski taramada olan hedefler
+	Changes []TargetChange
+}
+
+// TargetChange iki taramada da başarılı olan bir hedefteki değişiklikler
+type TargetChange struct {
+	URL           string
+	OldTag        string
+	NewTag        string
+	OldTitle      string
+	NewTitle      string
+	TextChanged   float64 // Görünen metindeki değişim yüzdesi, HTML yoksa -1
+	NewLinks      []string
+	RemovedLinks  []string
+	NewIndicators []string
+}
+
+// Compare iki tarama klasörünü (scan_result.json + HTML dosyaları) karşılaştırır
+func Compare(oldDir, newDir string) (*Report, error) {
+	oldResults, err := scanner.LoadResults(oldDir)
+	if err != nil {
+		return nil, fmt.Errorf("eski tarama okunamadı (%s): %v", oldDir, err)
+	}
+	newResults, err := scanner.LoadResults(newDir)
+	if err != nil {
+		return nil, fmt.Errorf("yeni tarama okunamadı (%s): %v", newDir, err)
+	}
+
+	rep := &Report{OldDir: oldDir, NewDir: newDir}
+	oldByURL := indexByURL(oldResults)
+	newByURL := indexByURL(newResults)
+
+	for _, u := range sortedKeys(newByURL) {
+		n := newByURL[u]
+		o, ok := oldByURL[u]
+		switch {
+		case !ok:
+			rep.Added = append(rep.Added, u)
+		case !o.Succeeded() && n.Succeeded():
+			rep.Up = append(rep.Up, u)
+		case o.Succeeded() && !n.Succeeded():
+			rep.Down = append(rep.Down, u)
+		case o.Succeeded() && n.Succeeded():
+			if c, changed := compareTarget(o, n, oldDir, newDir); changed {
+				rep.Changes = append(rep.Changes, c)
+			}
+		}
+	}
+	for _, u := range sortedKeys(oldByURL) {
+		if _, ok := newByURL[u]; !ok {
+			rep.Removed = append(rep.Removed, u)
+		}
+	}
+
+	return rep, nil
+}
+
+// compareTarget tek hedefin iki sonucunu karşılaştırır, değişiklik yoksa false döner
+func compareTarget(o, n scanner.ScanResult, oldDir, newDir string) (TargetChange, bool) {
+	c := TargetChange{
+		URL:         n.URL,
+		OldTag:      o.Tag,
+		NewTag:      n.Tag,
+		OldTitle:    o.Page.Title,
+		NewTitle:    n.Page.Title,
+		TextChanged: -1,
+	}
+
+	oldText, errOld := visibleText(oldDir, o.HTMLFile)
+	newText, errNew := visibleText(newDir, n.HTMLFile)
+	if errOld == nil && errNew == nil {
+		c.TextChanged = textChange(oldText, newText)
+	}
+
+	oldLinks := linkSet(o)
+	newLinks := linkSet(n)
+	c.NewLinks = setDiff(newLinks, oldLinks)
+	c.RemovedLinks = setDiff(oldLinks, newLinks)
+	c.NewIndicators = setDiff(indicatorSet(n), indicatorSet(o))
+
+	changed := c.OldTag != c.NewTag || c.OldTitle != c.NewTitle || c.TextChanged > 0 ||
+		len(c.NewLinks) > 0 || len(c.RemovedLinks) > 0 || len(c.NewIndicators) > 0
+	return c, changed
+}
+
+// WriteText raporu düz metin olarak yazar (linkler defang edilir)
+func (r *Report) WriteText(w io.Writer) {
+	border := strings.Repeat("=", 80)
+	fmt.Fprintf(w, "%s\n  TARAMA KARŞILAŞTIRMASI\n  ESKİ : %s\n  YENİ : %s\n%s\n", border, r.OldDir, r.NewDir, border)
+	fmt.Fprintf(w, "  Ayağa Kalkan: %d | Düşen: %d | Yeni Hedef: %d | Çıkarılan Hedef: %d | Değişen: %d\n\n",
+		len(r.Up), len(r.Down), len(r.Added), len(r.Removed), len(r.Changes))
+
+	writeList(w, "AYAĞA KALKAN HEDEFLER", r.Up)
+	writeList(w, "DÜŞEN HEDEFLER", r.Down)
+	writeList(w, "YENİ EKLENEN HEDEFLER", r.Added)
+	writeList(w, "LİSTEDEN ÇIKARILAN HEDEFLER", r.Removed)
+
+	if len(r.Changes) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "%s\n  DEĞİŞEN HEDEFLER\n%s\n", border, border)
+	for _, c := range r.Changes {
+		fmt.Fprintf(w, "\n  [*] %s\n", utils.Defang(c.URL))
+		if c.OldTag != c.NewTag {
+			fmt.Fprintf(w, "      Sınıflandırma : %s -> %s\n", c.OldTag, c.NewTag)
+		}
+		if c.OldTitle != c.NewTitle {
+			fmt.Fprintf(w, "      Başlık        : %q -> %q\n", c.OldTitle, c.NewTitle)
+		}
+		if c.TextChanged >= 0 {
+			fmt.Fprintf(w, "      Metin Değişimi: %%%.1f\n", c.TextChanged)
+		}
+		for _, l := range c.NewLinks {
+			fmt.Fprintf(w, "      [+] Link      : %s\n", utils.Defang(l))
+		}
+		for _, l := range c.RemovedLinks {
+			fmt.Fprintf(w, "      [-] Link      : %s\n", utils.Defang(l))
+		}
+		for _, i := range c.NewIndicators {
+			fmt.Fprintf(w, "      [+] Gösterge  : %s\n", utils.Defang(i))
+		}
+	}
+}
+
+// Save raporu yeni tarama klasörüne kaydeder
+func (r *Report) Save() error {
+	f, err := os.Create(filepath.Join(r.NewDir, ReportFile))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	r.WriteText(f)
+	return nil
+}
+
+func writeList(w io.Writer, title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "  %s (%d)\n", title, len(items))
+	for _, u := range items {
+		fmt.Fprintf(w, "    - %s\n", utils.Defang(u))
+	}
+	fmt.Fprintln(w)
+}
+
+// textChange iki metin arasındaki kelime bazlı değişim yüzdesi (eklenen + silinen / toplam)
+func textChange(oldText, newText string) float64 {
+	oldWords := wordCounts(oldText)
+	newWords := wordCounts(newText)
+
+	total, changed := 0, 0
+	for w, c := range oldWords {
+		total += c
+		if d := c - newWords[w]; d > 0 {
+			changed += d
+		}
+	}
+	for w, c := range newWords {
+		total += c
+		if d := c - oldWords[w]; d > 0 {
+			changed += d
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(changed) * 100 / float64(total)
+}
+
+func wordCounts(text string) map[string]int {
+	counts := map[string]int{}
+	for _, w := range strings.Fields(strings.ToLower(text)) {
+		counts[w]++
+	}
+	return counts
+}
+
+func visibleText(dir, file string) (string, error) {
+	if file == "" {
+		return "", fmt.Errorf("HTML dosyası yok")
+	}
+	data, err := os.ReadFile(filepath.Join(dir, file))
+	if err != nil {
+		return "", err
+	}
+	return classifier.VisibleText(string(data)), nil
+}
+
+// linkSet hedefin mutlak adrese çevrilmiş dış linkleri (aynı host'a giden menü/sayfa linkleri hariç)
+func linkSet(r scanner.ScanResult) map[string]bool {
+	set := map[string]bool{}
+
+	// Listedeki hedef şemasız yazılmış olabilir; taramadaki gibi http:// eklenir
+	target := r.URL
+	if !strings.HasPrefix(target, "http") {
+		target = "http://" + target
+	}
+	base, err := url.Parse(target)
+	if err != nil || base.Hostname() == "" {
+		return set
+	}
+	for _, l := range r.Links {
+		ref, err := url.Parse(l.URL)
+		if err != nil {
+			continue
+		}
+		abs := base.ResolveReference(ref)
+		if abs.Hostname() == "" || strings.EqualFold(abs.Hostname(), base.Hostname()) {
+			continue
+		}
+		set[abs.String()] = true
+	}
+	return set
+}
+
+// indicatorSet varlıkları, opsec bulgularını ve kurban kayıtlarını "tip: değer" olarak toplar
+func indicatorSet(r scanner.ScanResult) map[string]bool {
+	set := map[string]bool{}
+	for _, e := range r.Entities {
+		set[e.Type+": "+e.Value] = true
+	}
+	for _, f := range r.Opsec {
+		set[f.Type+": "+f.Value] = true
+	}
+	for _, v := range r.Victims {
+		set["victim: "+v.Name] = true
+	}
+	return set
+}
+
+func setDiff(a, b map[string]bool) []string {
+	var out []string
+	for k := range a {
+		if !b[k] {
+			out = append(out, k)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func indexByURL(results []scanner.ScanResult) map[string]scanner.ScanResult {
+	m := map[string]scanner.ScanResult{}
+	for _, r := range results {
+		// Aynı URL birden fazla taranmışsa başarılı olanı tut
+		if prev, ok := m[r.URL]; ok && prev.Succeeded() {
+			continue
+		}
+		m[r.URL] = r
+	}
+	return m
+}
+
+func sortedKeys(m map[string]scanner.ScanResult) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
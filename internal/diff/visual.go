@@ -0,0 +1,110 @@
+package diff
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"galileoff-OnionScraper/internal/imaging"
+	"galileoff-OnionScraper/internal/report"
+	"galileoff-OnionScraper/internal/scanner"
+	"galileoff-OnionScraper/internal/utils"
+)
+
+// VisualDiffDir işaretlenmiş fark görüntülerinin yazıldığı alt klasör
+const VisualDiffDir = "visual_diff"
+
+// VisualReportFile görsel karşılaştırma sonuçlarının listesi
+const VisualReportFile = "visual_diff.txt"
+
+// VisualChange bir hedefin ekran görüntüsündeki değişim
+type VisualChange struct {
+	URL      string
+	Score    float64 // Değişen alan yüzdesi
+	DiffFile string  // Eşik aşıldıysa işaretlenmiş fark görüntüsü (visual_diff/ altında)
+}
+
+// CompareScreenshots yeni taramadaki ekran görüntülerini önceki çalıştırmadaki aynı URL'in görüntüsüyle karşılaştırır.
+// Eşiği aşan hedefler için fark görüntüsü üretir; tüm skorlar visual_diff.txt dosyasına yazılır.
+// Dönen liste sadece eşiği aşan hedefleri içerir (skora göre azalan).
+func CompareScreenshots(oldDir, newDir string, results []scanner.ScanResult, threshold float64) ([]VisualChange, error) {
+	oldResults, err := scanner.LoadResults(oldDir)
+	if err != nil {
+		return nil, fmt.Errorf("önceki tarama okunamadı (%s): %v", oldDir, err)
+	}
+	oldShots := map[string]string{}
+	for _, r := range oldResults {
+		if r.Screenshot != "" {
+			oldShots[r.URL] = r.Screenshot
+		}
+	}
+
+	var compared, changed []VisualChange
+	for _, r := range results {
+		oldFile, ok := oldShots[r.URL]
+		if r.Screenshot == "" || !ok {
+			continue
+		}
+
+		oldImg, err := imaging.Load(filepath.Join(oldDir, oldFile))
+		if err != nil {
+			continue
+		}
+		newImg, err := imaging.Load(filepath.Join(newDir, r.Screenshot))
+		if err != nil {
+			continue
+		}
+
+		score, diffImg := imaging.Diff(oldImg, newImg)
+		c := VisualChange{URL: r.URL, Score: score}
+		if score >= threshold {
+			data, err := imaging.EncodePNG(diffImg)
+			if err != nil {
+				return nil, err
+			}
+			if err := os.MkdirAll(filepath.Join(newDir, VisualDiffDir), 0755); err != nil {
+				return nil, err
+			}
+			c.DiffFile = filepath.Join(VisualDiffDir, report.FileName(r.URL, "_diff.png"))
+			if err := os.WriteFile(filepath.Join(newDir, c.DiffFile), data, 0644); err != nil {
+				return nil, err
+			}
+			changed = append(changed, c)
+		}
+		compared = append(compared, c)
+	}
+
+	sort.Slice(compared, func(i, j int) bool { return compared[i].Score > compared[j].Score })
+	sort.Slice(changed, func(i, j int) bool { return changed[i].Score > changed[j].Score })
+
+	if err := saveVisualReport(oldDir, newDir, compared, len(changed), threshold); err != nil {
+		return changed, err
+	}
+	return changed, nil
+}
+
+func saveVisualReport(oldDir, newDir string, compared []VisualChange, changedCount int, threshold float64) error {
+	f, err := os.Create(filepath.Join(newDir, VisualReportFile))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	border := strings.Repeat("=", 80)
+	fmt.Fprintf(f, "%s\n  GÖRSEL KARŞILAŞTIRMA\n  ÖNCEKİ: %s\n  YENİ  : %s\n%s\n", border, oldDir, newDir, border)
+	fmt.Fprintf(f, "  Karşılaştırılan: %d | Eşiği (%%%.1f) Aşan: %d\n\n", len(compared), threshold, changedCount)
+	for _, c := range compared {
+		mark := " "
+		if c.DiffFile != "" {
+			mark = "!"
+		}
+		fmt.Fprintf(f, "  [%s] %-7s %s", mark, fmt.Sprintf("%%%.1f", c.Score), utils.Defang(c.URL))
+		if c.DiffFile != "" {
+			fmt.Fprintf(f, "  -> %s", c.DiffFile)
+		}
+		fmt.Fprintln(f)
+	}
+	return nil
+}
@@ -0,0 +1,267 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"galileoff-OnionScraper/internal/scanner"
+	"galileoff-OnionScraper/internal/utils"
+)
+
+// Link grafiği dosyaları
+const (
+	GraphMLFile     = "link_graph.graphml"
+	GEXFFile        = "link_graph.gexf"
+	DOTFile         = "link_graph.dot"
+	GraphRankFile   = "link_ranking.txt"
+	pageRankDamping = 0.85
+	pageRankIters   = 50
+)
+
+type graphNode struct {
+	ID        string
+	Tag       string
+	Status    string
+	FirstSeen time.Time
+	InDegree  int
+	PageRank  float64
+}
+
+type graphEdge struct {
+	Source string
+	Target string
+	Weight int
+}
+
+type linkGraph struct {
+	nodes map[string]*graphNode
+	edges []graphEdge
+}
+
+// SaveLinkGraph onion'lar arası link grafiğini GraphML, GEXF ve DOT olarak, sıralamayı metin olarak yazar
+func SaveLinkGraph(results []scanner.ScanResult, outputDir string) error {
+	g := buildLinkGraph(results)
+	g.rank()
+
+	writers := map[string]func(*os.File) error{
+		GraphMLFile:   g.writeGraphML,
+		GEXFFile:      g.writeGEXF,
+		DOTFile:       g.writeDOT,
+		GraphRankFile: g.writeRanking,
+	}
+	for name, write := range writers {
+		f, err := os.Create(filepath.Join(outputDir, name))
+		if err != nil {
+			return err
+		}
+		err = write(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s yazılamadı: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// buildLinkGraph taranan hedeflerden ve bağlantı verdikleri onion'lardan yönlü grafik kurar
+func buildLinkGraph(results []scanner.ScanResult) *linkGraph {
+	g := &linkGraph{nodes: map[string]*graphNode{}}
+
+	node := func(id string, seen time.Time) *graphNode {
+		n, ok := g.nodes[id]
+		if !ok {
+			n = &graphNode{ID: id, Tag: "-", Status: "NOT_SCANNED", FirstSeen: seen}
+			g.nodes[id] = n
+		}
+		if !seen.IsZero() && (n.FirstSeen.IsZero() || seen.Before(n.FirstSeen)) {
+			n.FirstSeen = seen
+		}
+		return n
+	}
+
+	for _, r := range results {
+		n := node(hostOf(r.URL), r.ScannedAt)
+		// Aynı host birden fazla URL ile taranmışsa başarılı olan sonucu tercih et
+		if n.Status != "SUCCESS" {
+			n.Status = r.Status
+			if r.Succeeded() {
+				n.Tag = r.Tag
+			}
+		}
+	}
+
+	// Aynı host birden fazla URL ile taranmışsa kenarlar tekrarlanmaz, ağırlıkları toplanır
+	weights := map[[2]string]int{}
+	for _, r := range results {
+		if !r.Succeeded() {
+			continue
+		}
+		src := hostOf(r.URL)
+		for host, count := range onionLinks(r) {
+			node(host, r.ScannedAt)
+			weights[[2]string{src, host}] += count
+		}
+	}
+	for key, weight := range weights {
+		g.edges = append(g.edges, graphEdge{Source: key[0], Target: key[1], Weight: weight})
+	}
+
+	sort.Slice(g.edges, func(i, j int) bool {
+		if g.edges[i].Source != g.edges[j].Source {
+			return g.edges[i].Source < g.edges[j].Source
+		}
+		return g.edges[i].Target < g.edges[j].Target
+	})
+	return g
+}
+
+// rank giriş derecesi ve PageRank değerlerini hesaplar
+func (g *linkGraph) rank() {
+	n := len(g.nodes)
+	if n == 0 {
+		return
+	}
+
+	outDegree := map[string]int{}
+	for _, e := range g.edges {
+		g.nodes[e.Target].InDegree++
+		outDegree[e.Source]++
+	}
+
+	rank := map[string]float64{}
+	for id := range g.nodes {
+		rank[id] = 1 / float64(n)
+	}
+
+	for i := 0; i < pageRankIters; i++ {
+		next := map[string]float64{}
+		// Dışarı linki olmayan düğümlerin puanı tüm grafiğe dağıtılır
+		dangling := 0.0
+		for id := range g.nodes {
+			if outDegree[id] == 0 {
+				dangling += rank[id]
+			}
+		}
+		for id := range g.nodes {
+			next[id] = (1-pageRankDamping)/float64(n) + pageRankDamping*dangling/float64(n)
+		}
+		for _, e := range g.edges {
+			next[e.Target] += pageRankDamping * rank[e.Source] / float64(outDegree[e.Source])
+		}
+		rank = next
+	}
+
+	for id, r := range rank {
+		g.nodes[id].PageRank = r
+	}
+}
+
+// sortedNodes düğümleri PageRank'e göre (eşitlikte isme göre) sıralar
+func (g *linkGraph) sortedNodes() []*graphNode {
+	nodes := make([]*graphNode, 0, len(g.nodes))
+	for _, n := range g.nodes {
+		nodes = append(nodes, n)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].PageRank != nodes[j].PageRank {
+			return nodes[i].PageRank > nodes[j].PageRank
+		}
+		return nodes[i].ID < nodes[j].ID
+	})
+	return nodes
+}
+
+func (n *graphNode) firstSeen() string {
+	if n.FirstSeen.IsZero() {
+		return ""
+	}
+	return n.FirstSeen.Format(time.RFC3339)
+}
+
+func (g *linkGraph) writeGraphML(f *os.File) error {
+	fmt.Fprintln(f, xml.Header+`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">`)
+	fmt.Fprintln(f, `  <key id="tag" for="node" attr.name="tag" attr.type="string"/>`)
+	fmt.Fprintln(f, `  <key id="status" for="node" attr.name="status" attr.type="string"/>`)
+	fmt.Fprintln(f, `  <key id="first_seen" for="node" attr.name="first_seen" attr.type="string"/>`)
+	fmt.Fprintln(f, `  <key id="in_degree" for="node" attr.name="in_degree" attr.type="int"/>`)
+	fmt.Fprintln(f, `  <key id="pagerank" for="node" attr.name="pagerank" attr.type="double"/>`)
+	fmt.Fprintln(f, `  <key id="weight" for="edge" attr.name="weight" attr.type="int"/>`)
+	fmt.Fprintln(f, `  <graph id="onion_links" edgedefault="directed">`)
+	for _, n := range g.sortedNodes() {
+		fmt.Fprintf(f, `    <node id="%s"><data key="tag">%s</data><data key="status">%s</data><data key="first_seen">%s</data><data key="in_degree">%d</data><data key="pagerank">%.6f</data></node>`+"\n",
+			xmlEscape(n.ID), xmlEscape(n.Tag), n.Status, n.firstSeen(), n.InDegree, n.PageRank)
+	}
+	for i, e := range g.edges {
+		fmt.Fprintf(f, `    <edge id="e%d" source="%s" target="%s"><data key="weight">%d</data></edge>`+"\n",
+			i, xmlEscape(e.Source), xmlEscape(e.Target), e.Weight)
+	}
+	fmt.Fprintln(f, `  </graph>`)
+	_, err := fmt.Fprintln(f, `</graphml>`)
+	return err
+}
+
+func (g *linkGraph) writeGEXF(f *os.File) error {
+	fmt.Fprintln(f, xml.Header+`<gexf xmlns="http://gexf.net/1.3" version="1.3">`)
+	fmt.Fprintf(f, "  <meta lastmodifieddate=\"%s\"><creator>galileoff. OnionScraper</creator></meta>\n", time.Now().Format("2006-01-02"))
+	fmt.Fprintln(f, `  <graph defaultedgetype="directed">`)
+	fmt.Fprintln(f, `    <attributes class="node">`)
+	fmt.Fprintln(f, `      <attribute id="0" title="tag" type="string"/>`)
+	fmt.Fprintln(f, `      <attribute id="1" title="status" type="string"/>`)
+	fmt.Fprintln(f, `      <attribute id="2" title="first_seen" type="string"/>`)
+	fmt.Fprintln(f, `      <attribute id="3" title="in_degree" type="integer"/>`)
+	fmt.Fprintln(f, `      <attribute id="4" title="pagerank" type="double"/>`)
+	fmt.Fprintln(f, `    </attributes>`)
+	fmt.Fprintln(f, `    <nodes>`)
+	for _, n := range g.sortedNodes() {
+		fmt.Fprintf(f, `      <node id="%s" label="%s"><attvalues><attvalue for="0" value="%s"/><attvalue for="1" value="%s"/><attvalue for="2" value="%s"/><attvalue for="3" value="%d"/><attvalue for="4" value="%.6f"/></attvalues></node>`+"\n",
+			xmlEscape(n.ID), xmlEscape(utils.Defang(n.ID)), xmlEscape(n.Tag), n.Status, n.firstSeen(), n.InDegree, n.PageRank)
+	}
+	fmt.Fprintln(f, `    </nodes>`)
+	fmt.Fprintln(f, `    <edges>`)
+	for i, e := range g.edges {
+		fmt.Fprintf(f, `      <edge id="%d" source="%s" target="%s" weight="%d"/>`+"\n", i, xmlEscape(e.Source), xmlEscape(e.Target), e.Weight)
+	}
+	fmt.Fprintln(f, `    </edges>`)
+	fmt.Fprintln(f, `  </graph>`)
+	_, err := fmt.Fprintln(f, `</gexf>`)
+	return err
+}
+
+func (g *linkGraph) writeDOT(f *os.File) error {
+	fmt.Fprintln(f, "digraph onion_links {")
+	fmt.Fprintln(f, `  rankdir=LR; node [shape=box, style=rounded, fontname="monospace"];`)
+	for _, n := range g.sortedNodes() {
+		style := ""
+		if n.Status != "SUCCESS" {
+			style = `, color="gray"`
+		}
+		fmt.Fprintf(f, "  %q [label=%q%s];\n", n.ID, fmt.Sprintf("%s\n%s (PR %.3f)", utils.Defang(n.ID), n.Tag, n.PageRank), style)
+	}
+	for _, e := range g.edges {
+		fmt.Fprintf(f, "  %q -> %q [weight=%d, label=\"%d\"];\n", e.Source, e.Target, e.Weight, e.Weight)
+	}
+	_, err := fmt.Fprintln(f, "}")
+	return err
+}
+
+// writeRanking en çok referans alan onion'ları PageRank sırasıyla yazar
+func (g *linkGraph) writeRanking(f *os.File) error {
+	border := strings.Repeat("=", 80)
+	fmt.Fprintf(f, "%s\n  LİNK GRAFİĞİ SIRALAMASI (%d Düğüm, %d Kenar)\n%s\n", border, len(g.nodes), len(g.edges), border)
+	fmt.Fprintf(f, "  %-4s %-10s %-9s %-20s %s\n", "#", "PAGERANK", "GİRİŞ", "ETİKET", "ADRES")
+	for i, n := range g.sortedNodes() {
+		fmt.Fprintf(f, "  %-4d %-10.4f %-9d %-20s %s\n", i+1, n.PageRank, n.InDegree, n.Tag, utils.Defang(n.ID))
+	}
+	return nil
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,256 @@
+package export
+
+import (
+	"encoding/base64"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"galileoff-OnionScraper/internal/imaging"
+	"galileoff-OnionScraper/internal/scanner"
+	"galileoff-OnionScraper/internal/utils"
+)
+
+// HTMLReportFile tek dosyalık HTML raporun adı
+const HTMLReportFile = "report.html"
+
+// Küçük resim boyutları (rapor dosyası şişmesin diye sayfanın üst kısmı)
+const (
+	thumbWidth   = 240
+	thumbHeight  = 180
+	thumbQuality = 70
+)
+
+type htmlRow struct {
+	URL        string
+	Status     string
+	Succeeded  bool
+	StatusCode int
+	Tag        string
+	Score      int
+	LinkCount  int
+	FetchMS    int64
+	ShotMS     int64
+	TotalMS    int64
+	Title      string
+	Thumbnail  template.URL
+	Links      []string
+	Error      string
+}
+
+type htmlCategory struct {
+	Tag   string
+	Count int
+}
+
+type htmlReport struct {
+	GeneratedAt string
+	OutputDir   string
+	Total       int
+	Success     int
+	Failed      int
+	Rows        []htmlRow
+	Categories  []htmlCategory
+}
+
+// SaveHTMLReport teknik olmayan paydaşlar için tek dosyalık, filtrelenebilir HTML rapor üretir
+func SaveHTMLReport(results []scanner.ScanResult, outputDir string) error {
+	data := htmlReport{
+		GeneratedAt: time.Now().Format("2006-01-02 15:04:05"),
+		OutputDir:   filepath.Base(outputDir),
+		Total:       len(results),
+	}
+
+	counts := map[string]int{}
+	for _, r := range results {
+		row := htmlRow{
+			URL:        utils.Defang(r.URL),
+			Status:     r.Status,
+			Succeeded:  r.Succeeded(),
+			StatusCode: r.StatusCode,
+			Tag:        r.Tag,
+			Score:      r.Score,
+			LinkCount:  r.LinkCount,
+			FetchMS:    r.Timings.FetchMS,
+			ShotMS:     r.Timings.ScreenshotMS,
+			TotalMS:    r.Timings.TotalMS,
+			Title:      r.Page.Title,
+			Error:      r.ErrorText,
+		}
+
+		if row.Succeeded {
+			data.Success++
+			counts[r.Tag]++
+		} else {
+			data.Failed++
+			row.Tag = "-"
+		}
+
+		for _, l := range r.Links {
+			row.Links = append(row.Links, utils.Defang(l.URL))
+		}
+
+		// Tarama sırasında üretilen küçük resim varsa tam sayfa görüntü yeniden çözülmez
+		if r.Thumbnail != "" {
+			row.Thumbnail = thumbnailURI(filepath.Join(outputDir, r.Thumbnail))
+		}
+		if row.Thumbnail == "" && r.Screenshot != "" {
+			row.Thumbnail = thumbnailURI(filepath.Join(outputDir, r.Screenshot))
+		}
+
+		data.Rows = append(data.Rows, row)
+	}
+
+	for tag, count := range counts {
+		data.Categories = append(data.Categories, htmlCategory{Tag: tag, Count: count})
+	}
+	sort.Slice(data.Categories, func(i, j int) bool {
+		if data.Categories[i].Count != data.Categories[j].Count {
+			return data.Categories[i].Count > data.Categories[j].Count
+		}
+		return data.Categories[i].Tag < data.Categories[j].Tag
+	})
+
+	f, err := os.Create(filepath.Join(outputDir, HTMLReportFile))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return htmlReportTemplate.Execute(f, data)
+}
+
+// thumbnailURI görüntüyü rapor boyutuna küçültüp data URI olarak döndürür (hata olursa boş)
+func thumbnailURI(path string) template.URL {
+	img, err := imaging.Load(path)
+	if err != nil {
+		return ""
+	}
+	jpg, err := imaging.EncodeJPEG(imaging.Thumbnail(img, thumbWidth, thumbHeight), thumbQuality)
+	if err != nil {
+		return ""
+	}
+	return template.URL("data:image/jpeg;base64," + base64.StdEncoding.EncodeToString(jpg))
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"lower": strings.ToLower,
+}).Parse(`<!DOCTYPE html>
+<html lang="tr">
+<head>
+<meta charset="utf-8">
+<title>galileoff. OnionScraper - Tarama Raporu ({{.OutputDir}})</title>
+<style>
+  body { font-family: -apple-system, "Segoe UI", Roboto, sans-serif; background: #0f1117; color: #e6e6e6; margin: 24px; }
+  h1 { font-size: 22px; margin: 0 0 4px; }
+  .muted { color: #8b8fa3; font-size: 13px; }
+  .cards { display: flex; gap: 12px; margin: 18px 0; flex-wrap: wrap; }
+  .card { background: #1a1d27; border-radius: 8px; padding: 12px 18px; min-width: 120px; }
+  .card b { display: block; font-size: 24px; }
+  .ok { color: #4ade80; } .fail { color: #f87171; }
+  table { border-collapse: collapse; width: 100%; background: #1a1d27; border-radius: 8px; overflow: hidden; }
+  th, td { padding: 8px 10px; border-bottom: 1px solid #2a2e3b; text-align: left; vertical-align: top; font-size: 13px; }
+  th { background: #232736; cursor: pointer; user-select: none; white-space: nowrap; }
+  th.sorted-asc::after { content: " ▲"; } th.sorted-desc::after { content: " ▼"; }
+  td.url { font-family: monospace; word-break: break-all; max-width: 360px; }
+  img.thumb { width: 240px; border-radius: 4px; border: 1px solid #2a2e3b; }
+  details summary { cursor: pointer; color: #93c5fd; }
+  details ul { margin: 6px 0 0; padding-left: 16px; font-family: monospace; font-size: 12px; max-height: 220px; overflow: auto; }
+  .filters { display: flex; gap: 10px; margin-bottom: 12px; }
+  input, select { background: #1a1d27; color: #e6e6e6; border: 1px solid #2a2e3b; border-radius: 6px; padding: 6px 10px; }
+  .summary td { font-size: 14px; }
+</style>
+</head>
+<body>
+<h1>galileoff. OnionScraper - Tarama Raporu</h1>
+<div class="muted">Klasör: {{.OutputDir}} &middot; Oluşturulma: {{.GeneratedAt}} &middot; Linkler güvenlik için defang edilmiştir ([.]onion)</div>
+
+<div class="cards">
+  <div class="card">Toplam Hedef<b>{{.Total}}</b></div>
+  <div class="card">Başarılı<b class="ok">{{.Success}}</b></div>
+  <div class="card">Başarısız<b class="fail">{{.Failed}}</b></div>
+</div>
+
+<h2>Kategori Özeti</h2>
+<table class="summary" style="width:auto;min-width:320px">
+  <tr><th>Etiket</th><th>Hedef Sayısı</th></tr>
+  {{range .Categories}}<tr><td>{{.Tag}}</td><td>{{.Count}}</td></tr>{{else}}<tr><td colspan="2">Başarılı hedef yok</td></tr>{{end}}
+</table>
+
+<h2>Hedefler</h2>
+<div class="filters">
+  <input id="q" type="search" placeholder="URL, başlık veya etiket ara..." size="40">
+  <select id="status"><option value="">Tüm Durumlar</option><option value="success">Başarılı</option><option value="failed">Başarısız</option></select>
+  <select id="tag"><option value="">Tüm Etiketler</option>{{range .Categories}}<option>{{.Tag}}</option>{{end}}</select>
+</div>
+<table id="targets">
+<thead><tr>
+  <th data-type="text">Ekran</th>
+  <th data-type="text">URL</th>
+  <th data-type="text">Durum</th>
+  <th data-type="num">HTTP</th>
+  <th data-type="text">Etiket</th>
+  <th data-type="num">Skor</th>
+  <th data-type="num">Link</th>
+  <th data-type="num">İstek (ms)</th>
+  <th data-type="num">Ekran (ms)</th>
+  <th data-type="num">Toplam (ms)</th>
+</tr></thead>
+<tbody>
+{{range .Rows}}<tr data-status="{{if .Succeeded}}success{{else}}failed{{end}}" data-tag="{{.Tag}}" data-search="{{lower .URL}} {{lower .Title}} {{lower .Tag}}">
+  <td>{{if .Thumbnail}}<img class="thumb" src="{{.Thumbnail}}" alt="ekran görüntüsü">{{else}}<span class="muted">yok</span>{{end}}</td>
+  <td class="url">{{.URL}}{{if .Title}}<div class="muted">{{.Title}}</div>{{end}}
+    {{if .Links}}<details><summary>{{len .Links}} link</summary><ul>{{range .Links}}<li>{{.}}</li>{{end}}</ul></details>{{end}}
+    {{if .Error}}<div class="fail">{{.Error}}</div>{{end}}</td>
+  <td class="{{if .Succeeded}}ok{{else}}fail{{end}}">{{.Status}}</td>
+  <td>{{if .StatusCode}}{{.StatusCode}}{{end}}</td>
+  <td>{{.Tag}}</td>
+  <td>{{.Score}}</td>
+  <td>{{.LinkCount}}</td>
+  <td>{{.FetchMS}}</td>
+  <td>{{.ShotMS}}</td>
+  <td>{{.TotalMS}}</td>
+</tr>
+{{end}}</tbody>
+</table>
+
+<script>
+(function () {
+  var table = document.getElementById("targets");
+  var body = table.tBodies[0];
+  var q = document.getElementById("q"), status = document.getElementById("status"), tag = document.getElementById("tag");
+
+  function filter() {
+    var text = q.value.toLowerCase();
+    Array.prototype.forEach.call(body.rows, function (row) {
+      var show = (!text || row.dataset.search.indexOf(text) !== -1) &&
+        (!status.value || row.dataset.status === status.value) &&
+        (!tag.value || row.dataset.tag === tag.value);
+      row.style.display = show ? "" : "none";
+    });
+  }
+  [q, status, tag].forEach(function (el) { el.addEventListener("input", filter); });
+
+  Array.prototype.forEach.call(table.tHead.rows[0].cells, function (th, idx) {
+    th.addEventListener("click", function () {
+      var asc = !th.classList.contains("sorted-asc");
+      Array.prototype.forEach.call(th.parentNode.cells, function (c) { c.classList.remove("sorted-asc", "sorted-desc"); });
+      th.classList.add(asc ? "sorted-asc" : "sorted-desc");
+      var num = th.dataset.type === "num";
+      var rows = Array.prototype.slice.call(body.rows);
+      rows.sort(function (a, b) {
+        var x = a.cells[idx].innerText.trim(), y = b.cells[idx].innerText.trim();
+        var r = num ? (parseFloat(x) || 0) - (parseFloat(y) || 0) : x.localeCompare(y);
+        return asc ? r : -r;
+      });
+      rows.forEach(function (r) { body.appendChild(r); });
+    });
+  });
+})();
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,198 @@
+package export
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"galileoff-OnionScraper/internal/config"
+	"galileoff-OnionScraper/internal/intel"
+	"galileoff-OnionScraper/internal/report"
+	"galileoff-OnionScraper/internal/scanner"
+	"galileoff-OnionScraper/internal/utils"
+)
+
+// MISPFile tarama başına üretilen MISP event dosyası
+const MISPFile = "misp_event.json"
+
+type mispTag struct {
+	Name string `json:"name"`
+}
+
+type mispAttribute struct {
+	UUID           string    `json:"uuid"`
+	Type           string    `json:"type"`
+	Category       string    `json:"category"`
+	Value          string    `json:"value"`
+	ObjectRelation string    `json:"object_relation,omitempty"`
+	Comment        string    `json:"comment,omitempty"`
+	ToIDS          bool      `json:"to_ids"`
+	Data           string    `json:"data,omitempty"`
+	Tag            []mispTag `json:"Tag,omitempty"`
+}
+
+type mispObject struct {
+	UUID         string          `json:"uuid"`
+	Name         string          `json:"name"`
+	MetaCategory string          `json:"meta-category"`
+	Comment      string          `json:"comment,omitempty"`
+	Attribute    []mispAttribute `json:"Attribute"`
+}
+
+type mispEvent struct {
+	UUID          string          `json:"uuid"`
+	Info          string          `json:"info"`
+	Date          string          `json:"date"`
+	ThreatLevelID string          `json:"threat_level_id"`
+	Analysis      string          `json:"analysis"`
+	Distribution  string          `json:"distribution"`
+	Published     bool            `json:"published"`
+	Tag           []mispTag       `json:"Tag"`
+	Attribute     []mispAttribute `json:"Attribute"`
+	Object        []mispObject    `json:"Object,omitempty"`
+}
+
+// SaveMISP taramayı MISP event JSON formatında yazar.
+// export.misp_per_target açıksa tek event yerine misp/ klasörüne hedef başına bir event yazılır.
+func SaveMISP(results []scanner.ScanResult, outputDir string) error {
+	if !config.GlobalSettings.Export.MISPPerTarget {
+		event := newMISPEvent(fmt.Sprintf("galileoff. OnionScraper taraması (%d hedef)", len(results)))
+		for _, r := range results {
+			if r.Succeeded() {
+				addTarget(event, r, outputDir)
+			}
+		}
+		return writeJSON(filepath.Join(outputDir, MISPFile), map[string]*mispEvent{"Event": event})
+	}
+
+	dir := filepath.Join(outputDir, "misp")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for _, r := range results {
+		if !r.Succeeded() {
+			continue
+		}
+		event := newMISPEvent("galileoff. OnionScraper: " + utils.Defang(r.URL))
+		addTarget(event, r, outputDir)
+		if err := writeJSON(filepath.Join(dir, report.FileName(r.URL, ".json")), map[string]*mispEvent{"Event": event}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newMISPEvent(info string) *mispEvent {
+	return &mispEvent{
+		UUID:          newUUID(),
+		Info:          info,
+		Date:          time.Now().Format("2006-01-02"),
+		ThreatLevelID: "4", // Tanımsız
+		Analysis:      "2", // Tamamlandı
+		Distribution:  "0", // Sadece kendi organizasyonum
+		Tag: []mispTag{
+			{Name: "tlp:amber"},
+			{Name: "galileoff-onionscraper"},
+		},
+	}
+}
+
+// addTarget hedefin URL/domain özniteliklerini, ekran görüntüsünü ve iletişim/cüzdan objelerini ekler
+func addTarget(event *mispEvent, r scanner.ScanResult, outputDir string) {
+	var tags []mispTag
+	if r.CategoryID != "" {
+		tags = append(tags, mispTag{Name: fmt.Sprintf(`onionscraper:category="%s"`, r.CategoryID)})
+		event.addEventTag(fmt.Sprintf(`onionscraper:category="%s"`, r.CategoryID))
+	}
+	comment := fmt.Sprintf("%s (Skor: %d)", r.Tag, r.Score)
+
+	event.Attribute = append(event.Attribute,
+		mispAttribute{UUID: newUUID(), Type: "url", Category: "Network activity", Value: r.URL, Comment: comment, Tag: tags},
+		mispAttribute{UUID: newUUID(), Type: "domain", Category: "Network activity", Value: hostOf(r.URL), Comment: comment, Tag: tags},
+	)
+
+	if r.Screenshot != "" {
+		if data, err := os.ReadFile(filepath.Join(outputDir, r.Screenshot)); err == nil {
+			event.Attribute = append(event.Attribute, mispAttribute{
+				UUID:     newUUID(),
+				Type:     "attachment",
+				Category: "External analysis",
+				Value:    r.Screenshot,
+				Comment:  "Ekran görüntüsü: " + utils.Defang(r.URL),
+				Data:     base64.StdEncoding.EncodeToString(data),
+			})
+		}
+	}
+
+	// Cüzdanlar coin-address, iletişim bilgileri ilgili hesap objeleri olarak eklenir
+	for _, e := range r.Entities {
+		source := "Kaynak: " + utils.Defang(r.URL)
+		switch {
+		case e.IsWallet():
+			event.Object = append(event.Object, mispObject{
+				UUID:         newUUID(),
+				Name:         "coin-address",
+				MetaCategory: "financial",
+				Comment:      source,
+				Attribute: []mispAttribute{
+					{UUID: newUUID(), Type: walletAttrType(e.Type), Category: "Financial fraud", ObjectRelation: "address", Value: e.Value, ToIDS: true},
+					{UUID: newUUID(), Type: "text", Category: "Other", ObjectRelation: "symbol", Value: strings.ToUpper(e.Type)},
+				},
+			})
+		case e.Type == intel.EntityTelegram:
+			event.Object = append(event.Object, mispObject{
+				UUID:         newUUID(),
+				Name:         "telegram-account",
+				MetaCategory: "misc",
+				Comment:      source,
+				Attribute: []mispAttribute{
+					{UUID: newUUID(), Type: "text", Category: "Social network", ObjectRelation: "username", Value: e.Value},
+				},
+			})
+		case e.Type == intel.EntityEmail:
+			event.Object = append(event.Object, mispObject{
+				UUID:         newUUID(),
+				Name:         "email",
+				MetaCategory: "network",
+				Comment:      source,
+				Attribute: []mispAttribute{
+					{UUID: newUUID(), Type: "email-src", Category: "Payload delivery", ObjectRelation: "from", Value: e.Value, ToIDS: true},
+				},
+			})
+		case e.Type == intel.EntityJabber:
+			event.Object = append(event.Object, mispObject{
+				UUID:         newUUID(),
+				Name:         "jabber-account",
+				MetaCategory: "misc",
+				Comment:      source,
+				Attribute: []mispAttribute{
+					{UUID: newUUID(), Type: "jabber-id", Category: "Social network", ObjectRelation: "jabber-id", Value: e.Value},
+				},
+			})
+		}
+	}
+}
+
+// walletAttrType cüzdan tipine karşılık gelen MISP öznitelik tipi (ETH için özel tip yok)
+func walletAttrType(kind string) string {
+	switch kind {
+	case intel.EntityBTC:
+		return "btc"
+	case intel.EntityXMR:
+		return "xmr"
+	}
+	return "text"
+}
+
+// addEventTag aynı etiketi tekrar eklemeden event'e etiket ekler
+func (e *mispEvent) addEventTag(name string) {
+	for _, t := range e.Tag {
+		if t.Name == name {
+			return
+		}
+	}
+	e.Tag = append(e.Tag, mispTag{Name: name})
+}
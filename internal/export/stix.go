@@ -0,0 +1,359 @@
+package export
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"galileoff-OnionScraper/internal/intel"
+	"galileoff-OnionScraper/internal/scanner"
+)
+
+// STIXFile STIX 2.1 paketinin yazıldığı dosya adı
+const STIXFile = "stix_bundle.json"
+
+// stixNamespace SCO kimlikleri için STIX 2.1 standardındaki UUIDv5 namespace'i
+var stixNamespace = [16]byte{0x00, 0xab, 0xed, 0xb4, 0xaa, 0x42, 0x46, 0x6c, 0x9c, 0x01, 0xfe, 0xd2, 0x33, 0x15, 0xa9, 0xb7}
+
+type stixObject map[string]interface{}
+
+// stixBundle paket içeriğini ve tekrar eden nesneleri takip eder
+type stixBundle struct {
+	objects  []stixObject
+	seen     map[string]bool
+	creator  string
+	hostRefs map[string]string // onion host -> infrastructure id
+}
+
+// SaveSTIX taramayı STIX 2.1 paketine çevirip çıktı klasörüne yazar
+func SaveSTIX(results []scanner.ScanResult, outputDir string) error {
+	now := stixTime(time.Now())
+	b := &stixBundle{
+		seen:     map[string]bool{},
+		hostRefs: map[string]string{},
+	}
+
+	// Paketi oluşturan araç
+	b.creator = "identity--" + newUUID()
+	b.add(stixObject{
+		"type":           "identity",
+		"spec_version":   "2.1",
+		"id":             b.creator,
+		"created":        now,
+		"modified":       now,
+		"name":           "galileoff. OnionScraper",
+		"identity_class": "system",
+	})
+
+	for _, r := range results {
+		if !r.Succeeded() {
+			continue
+		}
+		seenAt := stixTime(r.ScannedAt)
+
+		infraID := b.infrastructure(hostOf(r.URL), seenAt)
+		urlID := b.sco("url", r.URL, nil)
+		b.relationship(infraID, "consists-of", urlID, seenAt, "")
+
+		// Sınıflandırma etiketi ve skoru not olarak eklenir
+		if r.CategoryID != "" {
+			b.add(stixObject{
+				"type":           "note",
+				"spec_version":   "2.1",
+				"id":             "note--" + newUUID(),
+				"created":        seenAt,
+				"modified":       seenAt,
+				"created_by_ref": b.creator,
+				"abstract":       fmt.Sprintf("Sınıflandırma: %s", r.Tag),
+				"content":        fmt.Sprintf("%s sınıflandırma motoru tarafından %s (%s) olarak etiketlendi. Skor: %d", r.URL, r.Tag, r.CategoryID, r.Score),
+				"labels":         []string{r.CategoryID},
+				"object_refs":    []string{infraID, urlID},
+			})
+		}
+
+		// Opsec bulguları ve e-posta adreslerinden indicator üret
+		var iocs []intel.Finding
+		iocs = append(iocs, r.Opsec...)
+		for _, e := range r.Entities {
+			if e.Type == intel.EntityEmail {
+				iocs = append(iocs, intel.Finding{Type: e.Type, Value: e.Value})
+			}
+		}
+		for _, f := range iocs {
+			pattern, ok := indicatorPattern(f)
+			if !ok {
+				continue
+			}
+			b.indicator(f, pattern, infraID, seenAt)
+		}
+
+		// HTML, ekran görüntüsü ve arşiv çıktıları dosya adı + özetiyle file nesnesi olarak referanslanır
+		// (paket taşınsa da kanıt dosyası hash ile eşleştirilebilir)
+		files := []struct{ name, mime string }{
+			{r.HTMLFile, "text/html"},
+			{r.Screenshot, mime.TypeByExtension(filepath.Ext(r.Screenshot))},
+			{r.PDFFile, "application/pdf"},
+			{r.MHTMLFile, "multipart/related"},
+		}
+		for _, file := range files {
+			if file.name == "" {
+				continue
+			}
+			sum, size, err := fileSHA256(filepath.Join(outputDir, file.name))
+			if err != nil {
+				continue
+			}
+			fileID := b.sco("file", "", stixObject{
+				"name":      file.name,
+				"size":      size,
+				"mime_type": file.mime,
+				"hashes":    map[string]string{"SHA-256": sum},
+			})
+			b.relationship(infraID, "consists-of", fileID, seenAt, "")
+		}
+	}
+
+	// Link grafiği: onion'lar arası bağlantılar
+	for _, r := range results {
+		if !r.Succeeded() {
+			continue
+		}
+		seenAt := stixTime(r.ScannedAt)
+		srcID := b.hostRefs[hostOf(r.URL)]
+		for host, count := range onionLinks(r) {
+			dstID := b.infrastructure(host, seenAt)
+			b.relationship(srcID, "related-to", dstID, seenAt, fmt.Sprintf("Sayfada %d bağlantı", count))
+		}
+	}
+
+	bundle := stixObject{
+		"type":    "bundle",
+		"id":      "bundle--" + newUUID(),
+		"objects": b.objects,
+	}
+
+	return writeJSON(filepath.Join(outputDir, STIXFile), bundle)
+}
+
+func (b *stixBundle) add(obj stixObject) {
+	id := obj["id"].(string)
+	if b.seen[id] {
+		return
+	}
+	b.seen[id] = true
+	b.objects = append(b.objects, obj)
+}
+
+// infrastructure onion host'u için tekil bir infrastructure nesnesi döndürür
+func (b *stixBundle) infrastructure(host, seenAt string) string {
+	if id, ok := b.hostRefs[host]; ok {
+		return id
+	}
+	id := "infrastructure--" + uuidV5("infrastructure|"+host)
+	b.hostRefs[host] = id
+	b.add(stixObject{
+		"type":           "infrastructure",
+		"spec_version":   "2.1",
+		"id":             id,
+		"created":        seenAt,
+		"modified":       seenAt,
+		"created_by_ref": b.creator,
+		"name":           host,
+		"first_seen":     seenAt,
+	})
+	return id
+}
+
+// sco değer tabanlı deterministik kimlikle bir gözlemlenebilir (SCO) ekler
+func (b *stixBundle) sco(kind, value string, extra stixObject) string {
+	idProps := stixObject{}
+	obj := stixObject{
+		"type":         kind,
+		"spec_version": "2.1",
+	}
+	if value != "" {
+		obj["value"] = value
+		idProps["value"] = value
+	}
+	for k, v := range extra {
+		obj[k] = v
+	}
+	// Kimliğe katkı veren özellikler (file için hashes + name)
+	for _, k := range []string{"hashes", "name"} {
+		if v, ok := extra[k]; ok {
+			idProps[k] = v
+		}
+	}
+
+	id := kind + "--" + uuidV5(canonicalJSON(idProps))
+	obj["id"] = id
+	b.add(obj)
+	return id
+}
+
+// indicator IOC için (tekil) indicator nesnesi ekler ve altyapıya bağlar
+func (b *stixBundle) indicator(f intel.Finding, pattern, infraID, seenAt string) {
+	id := "indicator--" + uuidV5("indicator|"+pattern)
+	obj := stixObject{
+		"type":            "indicator",
+		"spec_version":    "2.1",
+		"id":              id,
+		"created":         seenAt,
+		"modified":        seenAt,
+		"created_by_ref":  b.creator,
+		"name":            fmt.Sprintf("%s: %s", f.Type, f.Value),
+		"indicator_types": []string{"attribution"},
+		"pattern":         pattern,
+		"pattern_type":    "stix",
+		"valid_from":      seenAt,
+		"labels":          []string{f.Type},
+	}
+	if f.Evidence != "" {
+		obj["description"] = f.Evidence
+	}
+	b.add(obj)
+	b.relationship(id, "indicates", infraID, seenAt, "")
+}
+
+func (b *stixBundle) relationship(src, relType, dst, seenAt, description string) {
+	if src == "" || dst == "" || src == dst {
+		return
+	}
+	obj := stixObject{
+		"type":              "relationship",
+		"spec_version":      "2.1",
+		"id":                "relationship--" + uuidV5(src+"|"+relType+"|"+dst),
+		"created":           seenAt,
+		"modified":          seenAt,
+		"created_by_ref":    b.creator,
+		"relationship_type": relType,
+		"source_ref":        src,
+		"target_ref":        dst,
+	}
+	if description != "" {
+		obj["description"] = description
+	}
+	b.add(obj)
+}
+
+// indicatorPattern bulgu tipini STIX pattern'ine çevirir (karşılığı olmayanlar atlanır)
+func indicatorPattern(f intel.Finding) (string, bool) {
+	value := strings.ReplaceAll(strings.ReplaceAll(f.Value, `\`, `\\`), `'`, `\'`)
+	switch f.Type {
+	case intel.FindingClearnetIP, intel.FindingPrivateIP:
+		return fmt.Sprintf("[ipv4-addr:value = '%s']", value), true
+	case intel.FindingClearnetDomain, intel.FindingBucket:
+		return fmt.Sprintf("[domain-name:value = '%s']", value), true
+	case intel.EntityEmail:
+		return fmt.Sprintf("[email-addr:value = '%s']", value), true
+	}
+	return "", false
+}
+
+// onionLinks sonucun bağlantı verdiği diğer onion host'larını ve link sayısını döndürür
+func onionLinks(r scanner.ScanResult) map[string]int {
+	base, err := url.Parse(r.URL)
+	if err != nil {
+		return nil
+	}
+	self := strings.ToLower(base.Hostname())
+
+	hosts := map[string]int{}
+	for _, l := range r.Links {
+		ref, err := url.Parse(l.URL)
+		if err != nil {
+			continue
+		}
+		host := strings.ToLower(base.ResolveReference(ref).Hostname())
+		if host == "" || host == self || !strings.HasSuffix(host, ".onion") {
+			continue
+		}
+		hosts[host]++
+	}
+	return hosts
+}
+
+// hostOf URL'in host kısmını döndürür
+func hostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Hostname() == "" {
+		return rawURL
+	}
+	return strings.ToLower(u.Hostname())
+}
+
+// fileSHA256 dosyanın SHA-256 özetini ve boyutunu döndürür
+func fileSHA256(path string) (string, int64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", 0, err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), int64(len(data)), nil
+}
+
+// stixTime STIX'in beklediği UTC milisaniye hassasiyetli zaman formatı
+func stixTime(t time.Time) string {
+	if t.IsZero() {
+		t = time.Now()
+	}
+	return t.UTC().Format("2006-01-02T15:04:05.000Z")
+}
+
+// writeJSON HTML kaçışı yapmadan girintili JSON dosyası yazar (URL'lerdeki & bozulmasın)
+func writeJSON(path string, v interface{}) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// canonicalJSON HTML kaçışı yapmadan, anahtarları sıralı JSON üretir
+func canonicalJSON(v interface{}) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(v)
+	return strings.TrimSpace(buf.String())
+}
+
+// newUUID rastgele UUIDv4 üretir
+func newUUID() string {
+	var u [16]byte
+	rand.Read(u[:])
+	u[6] = (u[6] & 0x0f) | 0x40
+	u[8] = (u[8] & 0x3f) | 0x80
+	return formatUUID(u)
+}
+
+// uuidV5 STIX namespace'i ile isim tabanlı UUIDv5 üretir
+func uuidV5(name string) string {
+	h := sha1.New()
+	h.Write(stixNamespace[:])
+	h.Write([]byte(name))
+	sum := h.Sum(nil)
+
+	var u [16]byte
+	copy(u[:], sum[:16])
+	u[6] = (u[6] & 0x0f) | 0x50
+	u[8] = (u[8] & 0x3f) | 0x80
+	return formatUUID(u)
+}
+
+func formatUUID(u [16]byte) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:16])
+}
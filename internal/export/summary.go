@@ -0,0 +1,140 @@
+package export
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"galileoff-OnionScraper/internal/config"
+	"galileoff-OnionScraper/internal/intel"
+	"galileoff-OnionScraper/internal/scanner"
+	"galileoff-OnionScraper/internal/utils"
+)
+
+// Özet tablo dosyaları (scan_result.log ile aynı klasörde)
+const (
+	SummaryCSVFile  = "scan_summary.csv"
+	SummaryXLSXFile = "scan_summary.xlsx"
+)
+
+// summaryColumns özet tablonun başlıkları (CSV ve XLSX aynı sırayı kullanır)
+var summaryColumns = []string{
+	"url", "status", "status_code", "tag", "category_id", "score", "title", "link_count",
+	"emails", "jabber", "telegram", "wallets", "opsec_findings", "victims", "clearnet_hosts",
+	"fetch_ms", "gate_ms", "screenshot_ms", "total_ms", "html_file", "screenshot_file", "pdf_file", "mhtml_file", "har_file", "scanned_at", "error",
+}
+
+// summaryCell tablodaki tek hücre; Number true ise XLSX'e sayı olarak yazılır
+type summaryCell struct {
+	Value  string
+	Number bool
+}
+
+// SaveSummary her hedef için bir satır içeren CSV özet üretir.
+// export.xlsx açıksa aynı içerikte XLSX dosyası da yazılır.
+func SaveSummary(results []scanner.ScanResult, outputDir string) error {
+	rows := make([][]summaryCell, 0, len(results))
+	for _, r := range results {
+		rows = append(rows, summaryRow(r))
+	}
+
+	if err := saveSummaryCSV(rows, filepath.Join(outputDir, SummaryCSVFile)); err != nil {
+		return err
+	}
+	if config.GlobalSettings.Export.XLSX {
+		return saveXLSX(summaryColumns, rows, filepath.Join(outputDir, SummaryXLSXFile))
+	}
+	return nil
+}
+
+func summaryRow(r scanner.ScanResult) []summaryCell {
+	counts := map[string]int{}
+	wallets := 0
+	for _, e := range r.Entities {
+		counts[e.Type]++
+		if e.IsWallet() {
+			wallets++
+		}
+	}
+	// Render sırasında bağlanılan açık ağ sunucuları (ziyaretçiyi ifşa eden çağrılar)
+	clearnet := 0
+	for _, h := range r.Hosts {
+		if !h.Onion {
+			clearnet++
+		}
+	}
+
+	text := func(s string) summaryCell { return summaryCell{Value: s} }
+	num := func(n int64) summaryCell { return summaryCell{Value: strconv.FormatInt(n, 10), Number: true} }
+
+	scannedAt := ""
+	if !r.ScannedAt.IsZero() {
+		scannedAt = r.ScannedAt.Format("2006-01-02 15:04:05")
+	}
+
+	return []summaryCell{
+		text(utils.Defang(r.URL)),
+		text(r.Status),
+		num(int64(r.StatusCode)),
+		text(r.Tag),
+		text(r.CategoryID),
+		num(int64(r.Score)),
+		text(r.Page.Title),
+		num(int64(r.LinkCount)),
+		num(int64(counts[intel.EntityEmail])),
+		num(int64(counts[intel.EntityJabber])),
+		num(int64(counts[intel.EntityTelegram])),
+		num(int64(wallets)),
+		num(int64(len(r.Opsec))),
+		num(int64(len(r.Victims))),
+		num(int64(clearnet)),
+		num(r.Timings.FetchMS),
+		num(r.Timings.GateMS),
+		num(r.Timings.ScreenshotMS),
+		num(r.Timings.TotalMS),
+		text(r.HTMLFile),
+		text(r.Screenshot),
+		text(r.PDFFile),
+		text(r.MHTMLFile),
+		text(r.HARFile),
+		text(scannedAt),
+		text(r.ErrorText),
+	}
+}
+
+func saveSummaryCSV(rows [][]summaryCell, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// UTF-8 BOM: Excel Türkçe karakterleri doğru göstersin
+	f.WriteString("\xEF\xBB\xBF")
+
+	w := csv.NewWriter(f)
+	w.Write(summaryColumns)
+	for _, row := range rows {
+		record := make([]string, len(row))
+		for i, c := range row {
+			record[i] = c.Value
+			if !c.Number {
+				record[i] = neutralizeFormula(c.Value)
+			}
+		}
+		w.Write(record)
+	}
+	w.Flush()
+
+	return w.Error()
+}
+
+// neutralizeFormula sayfa başlığı gibi dış kaynaklı metinlerin tablo programında formül olarak çalışmasını engeller
+func neutralizeFormula(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
@@ -0,0 +1,124 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// XLSX dosyası için gereken sabit parçalar (tek sayfa, paylaşılan metin tablosu yok)
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>
+</Types>`
+
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Tarama" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`
+
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+</Relationships>`
+
+	// Stil 0: normal, stil 1: kalın başlık
+	xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>
+<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>
+</styleSheet>`
+)
+
+// saveXLSX başlık satırı kalın, donuk ve filtreli tek sayfalık bir XLSX dosyası yazar (harici bağımlılık yok)
+func saveXLSX(header []string, rows [][]summaryCell, path string) error {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+		{"xl/worksheets/sheet1.xml", xlsxSheet(header, rows)},
+	}
+	for _, p := range parts {
+		w, err := zw.Create(p.name)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write([]byte(p.body)); err != nil {
+			return err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+func xlsxSheet(header []string, rows [][]summaryCell) string {
+	var sb strings.Builder
+	lastCol := columnName(len(header) - 1)
+
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>
+<sheetData>`)
+
+	headerCells := make([]summaryCell, len(header))
+	for i, h := range header {
+		headerCells[i] = summaryCell{Value: h}
+	}
+	writeXLSXRow(&sb, 1, headerCells, 1)
+	for i, row := range rows {
+		writeXLSXRow(&sb, i+2, row, 0)
+	}
+
+	fmt.Fprintf(&sb, `</sheetData><autoFilter ref="A1:%s%d"/></worksheet>`, lastCol, len(rows)+1)
+	return sb.String()
+}
+
+func writeXLSXRow(sb *strings.Builder, rowNum int, cells []summaryCell, style int) {
+	fmt.Fprintf(sb, `<row r="%d">`, rowNum)
+	for i, c := range cells {
+		ref := fmt.Sprintf("%s%d", columnName(i), rowNum)
+		if c.Number {
+			fmt.Fprintf(sb, `<c r="%s" s="%d"><v>%s</v></c>`, ref, style, c.Value)
+			continue
+		}
+		// Satır içi metin; xml.EscapeText geçersiz kontrol karakterlerini de temizler
+		fmt.Fprintf(sb, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">`, ref, style)
+		xml.EscapeText(sb, []byte(c.Value))
+		sb.WriteString(`</t></is></c>`)
+	}
+	sb.WriteString(`</row>`)
+}
+
+// columnName 0 tabanlı sütun indeksini Excel harfine çevirir (0 -> A, 26 -> AA)
+func columnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
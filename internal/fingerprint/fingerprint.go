@@ -0,0 +1,115 @@
+package fingerprint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// Fingerprints bir sayfanın operatör/kit eşleştirmesinde kullanılan parmak izleri
+type Fingerprints struct {
+	FaviconURL  string   `json:"favicon_url,omitempty"`
+	FaviconHash int32    `json:"favicon_hash,omitempty"` // Shodan uyumlu (http.favicon.hash)
+	TitleHash   string   `json:"title_hash,omitempty"`
+	HeaderOrder []string `json:"header_order,omitempty"`
+	HeaderHash  string   `json:"header_hash,omitempty"`
+	DOMHash     string   `json:"dom_hash,omitempty"`
+}
+
+// Compute favicon dışındaki parmak izlerini hesaplar.
+// headerOrder boşsa (TLS vb. nedeniyle ham sıra okunamadıysa) header isimleri alfabetik sıralanır.
+func Compute(htmlContent string, headerOrder []string, headerNames []string, server string) Fingerprints {
+	var fp Fingerprints
+
+	if len(headerOrder) == 0 {
+		headerOrder = append([]string(nil), headerNames...)
+		sort.Strings(headerOrder)
+	}
+	fp.HeaderOrder = headerOrder
+	fp.HeaderHash = shortHash(strings.ToLower(strings.Join(headerOrder, ",")) + "|" + server)
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+	if err != nil {
+		return fp
+	}
+
+	title := strings.ToLower(strings.Join(strings.Fields(doc.Find("title").Text()), " "))
+	if title != "" {
+		fp.TitleHash = shortHash(title)
+	}
+
+	fp.DOMHash = DOMHash(doc)
+	return fp
+}
+
+// DOMHash sayfanın etiket iskeletinden (metin ve öznitelik değerleri hariç) hash üretir.
+// Aynı kit ile kurulmuş siteler farklı içerikle de olsa aynı iskelete sahiptir.
+func DOMHash(doc *goquery.Document) string {
+	var b strings.Builder
+
+	var walk func(n *html.Node, depth int)
+	walk = func(n *html.Node, depth int) {
+		if n.Type == html.ElementNode {
+			// Betik ve stil içerikleri sık değiştiği için sadece etiket olarak sayılır
+			fmt.Fprintf(&b, "%d:%s;", depth, n.Data)
+			if n.Data == "script" || n.Data == "style" {
+				return
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c, depth+1)
+		}
+	}
+
+	for _, n := range doc.Nodes {
+		walk(n, 0)
+	}
+
+	if b.Len() == 0 {
+		return ""
+	}
+	return shortHash(b.String())
+}
+
+// FaviconURL sayfadaki icon linkini bulur, yoksa varsayılan /favicon.ico adresini döndürür
+func FaviconURL(htmlContent, pageURL string) string {
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return ""
+	}
+
+	href := ""
+	if doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent)); err == nil {
+		doc.Find("link[rel]").EachWithBreak(func(i int, s *goquery.Selection) bool {
+			rel, _ := s.Attr("rel")
+			if strings.Contains(strings.ToLower(rel), "icon") {
+				href, _ = s.Attr("href")
+				href = strings.TrimSpace(href)
+				return href == ""
+			}
+			return true
+		})
+	}
+
+	if href == "" || strings.HasPrefix(href, "data:") {
+		href = "/favicon.ico"
+	}
+
+	ref, err := url.Parse(href)
+	if err != nil {
+		return ""
+	}
+	return base.ResolveReference(ref).String()
+}
+
+// shortHash SHA-256 hash'inin ilk 16 hex karakterini döndürür
+func shortHash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])[:16]
+}
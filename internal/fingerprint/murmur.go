@@ -0,0 +1,76 @@
+package fingerprint
+
+import (
+	"encoding/base64"
+	"math/bits"
+	"strings"
+)
+
+// FaviconHash Shodan ile uyumlu favicon hash'i hesaplar (http.favicon.hash).
+// Shodan, favicon'u satır başına 76 karakterlik base64'e çevirip MurmurHash3 (x86, 32 bit, seed 0) uygular.
+func FaviconHash(data []byte) int32 {
+	return int32(murmur3([]byte(encodeBase64Lines(data)), 0))
+}
+
+// encodeBase64Lines Python'daki base64.encodebytes çıktısını taklit eder
+func encodeBase64Lines(data []byte) string {
+	encoded := base64.StdEncoding.EncodeToString(data)
+
+	var b strings.Builder
+	for len(encoded) > 76 {
+		b.WriteString(encoded[:76])
+		b.WriteByte('\n')
+		encoded = encoded[76:]
+	}
+	b.WriteString(encoded)
+	b.WriteByte('\n')
+	return b.String()
+}
+
+// murmur3 MurmurHash3 x86 32 bit implementasyonu
+func murmur3(data []byte, seed uint32) uint32 {
+	const (
+		c1 = 0xcc9e2d51
+		c2 = 0x1b873593
+	)
+
+	h := seed
+	nblocks := len(data) / 4
+
+	for i := 0; i < nblocks; i++ {
+		k := uint32(data[i*4]) | uint32(data[i*4+1])<<8 | uint32(data[i*4+2])<<16 | uint32(data[i*4+3])<<24
+		k *= c1
+		k = bits.RotateLeft32(k, 15)
+		k *= c2
+
+		h ^= k
+		h = bits.RotateLeft32(h, 13)
+		h = h*5 + 0xe6546b64
+	}
+
+	// Kalan baytlar
+	tail := data[nblocks*4:]
+	var k uint32
+	switch len(tail) {
+	case 3:
+		k ^= uint32(tail[2]) << 16
+		fallthrough
+	case 2:
+		k ^= uint32(tail[1]) << 8
+		fallthrough
+	case 1:
+		k ^= uint32(tail[0])
+		k *= c1
+		k = bits.RotateLeft32(k, 15)
+		k *= c2
+		h ^= k
+	}
+
+	h ^= uint32(len(data))
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	h *= 0xc2b2ae35
+	h ^= h >> 16
+	return h
+}
@@ -0,0 +1,112 @@
+package gate
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Geçit türleri
+const (
+	KindQueue     = "queue"     // Bekleme sırası: bir süre sonra kendiliğinden siteye geçer
+	KindChallenge = "challenge" // JavaScript / proof-of-work tarayıcı kontrolü
+	KindCaptcha   = "captcha"   // İnsan doğrulaması gerekir
+)
+
+// Tag ve CategoryID geçit sayfalarının sınıflandırma etiketi
+const (
+	Tag        = "[GEÇİT SAYFASI]"
+	CategoryID = "gate"
+)
+
+// maxGateText bu uzunluktan fazla görünen metne sahip sayfalar geçit sayılmaz
+// (captcha'lı giriş formu olan gerçek bir forum sayfasını geçitle karıştırmamak için)
+const maxGateText = 2500
+
+// Detection tespit edilen ara sayfa (DDoS koruması, kuyruk, captcha)
+type Detection struct {
+	Kind       string        `json:"kind"`
+	Name       string        `json:"name"`     // Tanınan ürün (EndGame vb.) veya "Genel"
+	Evidence   string        `json:"evidence"` // Eşleşen metin
+	RetryAfter time.Duration `json:"-"`        // Sayfanın bildirdiği bekleme süresi (meta refresh / Retry-After)
+}
+
+// Passable bekleyerek (insan müdahalesi olmadan) geçilebilen bir geçit mi
+func (d *Detection) Passable() bool {
+	return d.Kind == KindQueue
+}
+
+type signature struct {
+	name    string
+	kind    string
+	pattern *regexp.Regexp
+}
+
+// Sıra önemli: daha belirgin imzalar önce
+var signatures = []signature{
+	{"EndGame", KindCaptcha, regexp.MustCompile(`(?i)endgame[^<]{0,80}(captcha|verif|ddos|queue)|(captcha|verif|ddos|queue)[^<]{0,80}endgame`)},
+	{"Genel", KindCaptcha, regexp.MustCompile(`(?i)(solve|enter|complete) the captcha|captcha (is )?required|(prove|verify|confirm) (that )?you('| a)?re (a )?human|i('| a)?m not a robot|human verification|captcha'?y?[ıi] (çözün|girin)`)},
+	{"Genel", KindQueue, regexp.MustCompile(`(?i)you are (now )?in (the |a )?queue|(your )?position in (the )?queue|queue position|waiting room|too many (users|visitors)|site is (currently )?under (heavy )?load|sıradasınız|bekleme sırası`)},
+	{"Genel", KindChallenge, regexp.MustCompile(`(?i)checking your browser|ddos[- ]?(protection|guard|filter)|anti[- ]?ddos|browser (check|verification)|please wait while we (verify|check)|javascript (is )?required to (continue|access)|proof[- ]of[- ]work`)},
+}
+
+var (
+	reMetaRefresh  = regexp.MustCompile(`(?i)<meta[^>]+http-equiv=["']?refresh["']?[^>]*content=["']?\s*(\d+)`)
+	reCaptchaInput = regexp.MustCompile(`(?i)<input[^>]+name=["']?[^"'\s>]*captcha`)
+)
+
+// Detect sayfanın asıl site yerine bir DDoS koruması / kuyruk / captcha geçidi olup olmadığını tespit eder.
+// text sayfanın görünen metnidir; geçit değilse nil döner.
+func Detect(content, text string, statusCode int, header http.Header) *Detection {
+	text = strings.TrimSpace(text)
+	if len(text) > maxGateText {
+		return nil
+	}
+
+	retryAfter := retryAfter(content, header)
+
+	for _, s := range signatures {
+		if m := s.pattern.FindString(text + " " + titleOf(content)); m != "" {
+			return &Detection{Kind: s.kind, Name: s.name, Evidence: m, RetryAfter: retryAfter}
+		}
+	}
+
+	// Kısa sayfada captcha alanı olan tek form
+	if m := reCaptchaInput.FindString(content); m != "" && strings.Count(strings.ToLower(content), "<form") == 1 {
+		return &Detection{Kind: KindCaptcha, Name: "Genel", Evidence: m, RetryAfter: retryAfter}
+	}
+
+	// Yenileme süresi bildiren 503/429 yanıtı bekleme sırasıdır; sadece meta refresh ("5 sn sonra yönlendirileceksiniz")
+	// geçit sayılmaz, kuyruk metni yukarıdaki imzalarla ayrıca aranır
+	if retryAfter > 0 && (statusCode == http.StatusServiceUnavailable || statusCode == http.StatusTooManyRequests) {
+		return &Detection{Kind: KindQueue, Name: "Genel", Evidence: fmt.Sprintf("HTTP %d, yenileme: %s", statusCode, retryAfter), RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// retryAfter meta refresh veya Retry-After başlığından bekleme süresini okur
+func retryAfter(content string, header http.Header) time.Duration {
+	if header != nil {
+		if v, err := strconv.Atoi(strings.TrimSpace(header.Get("Retry-After"))); err == nil && v > 0 {
+			return time.Duration(v) * time.Second
+		}
+	}
+	if m := reMetaRefresh.FindStringSubmatch(content); m != nil {
+		if v, err := strconv.Atoi(m[1]); err == nil && v > 0 {
+			return time.Duration(v) * time.Second
+		}
+	}
+	return 0
+}
+
+var reTitle = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+
+func titleOf(content string) string {
+	if m := reTitle.FindStringSubmatch(content); m != nil {
+		return m[1]
+	}
+	return ""
+}
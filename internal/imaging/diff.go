@@ -0,0 +1,81 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+)
+
+// PixelTolerance bir kanal farkı bu değerin altındaysa piksel değişmemiş sayılır (JPEG / anti-aliasing gürültüsü)
+const PixelTolerance = 40
+
+// Diff iki ekran görüntüsünü karşılaştırır, değişen alanın yüzdesini ve
+// değişen pikselleri kırmızıyla işaretlenmiş (geri kalanı soluklaştırılmış) görüntüyü döndürür.
+// Eski görüntü yeninin genişliğine ölçeklenir; sadece birinde olan satırlar değişmiş sayılır.
+func Diff(oldImg, newImg image.Image) (float64, *image.RGBA) {
+	nb := newImg.Bounds()
+	width, newHeight := nb.Dx(), nb.Dy()
+	if width == 0 || newHeight == 0 {
+		return 0, image.NewRGBA(image.Rect(0, 0, 0, 0))
+	}
+
+	cur := toRGBA(newImg)
+	prev := toRGBA(Thumbnail(oldImg, width, 0))
+	prevHeight := prev.Bounds().Dy()
+
+	out := image.NewRGBA(image.Rect(0, 0, width, newHeight))
+	changed := 0
+	for y := 0; y < newHeight; y++ {
+		for x := 0; x < width; x++ {
+			c := cur.RGBAAt(x, y)
+			if y >= prevHeight || pixelChanged(c, prev.RGBAAt(x, y)) {
+				changed++
+				out.SetRGBA(x, y, color.RGBA{255, c.G / 3, c.B / 3, 255})
+				continue
+			}
+			// Değişmeyen alanı gri ve soluk göster ki kırmızı alanlar öne çıksın
+			gray := uint8((uint32(c.R)*299 + uint32(c.G)*587 + uint32(c.B)*114) / 1000)
+			faded := 255 - (255-gray)/3
+			out.SetRGBA(x, y, color.RGBA{faded, faded, faded, 255})
+		}
+	}
+
+	// Eski sayfa daha uzunsa fazlalık satırlar da değişiklik sayılır
+	total := width * max(newHeight, prevHeight)
+	if prevHeight > newHeight {
+		changed += width * (prevHeight - newHeight)
+	}
+	return float64(changed) * 100 / float64(total), out
+}
+
+// EncodePNG görüntüyü PNG baytlarına çevirir
+func EncodePNG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func pixelChanged(a, b color.RGBA) bool {
+	return absDiff(a.R, b.R) > PixelTolerance || absDiff(a.G, b.G) > PixelTolerance || absDiff(a.B, b.B) > PixelTolerance
+}
+
+func absDiff(a, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+func toRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok && rgba.Bounds().Min == (image.Point{}) {
+		return rgba
+	}
+	b := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, b.Min, draw.Src)
+	return rgba
+}
@@ -0,0 +1,118 @@
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// Etiketler Go Mono ile yazılır: eş aralıklı olduğu için URL'ler hizalı durur,
+// Türkçe karakterleri (ç, ğ, ı, İ, ö, ş, ü) içerir ve font dosyası binary'ye gömülüdür.
+const labelFontSize = 11
+
+var (
+	faceOnce  sync.Once
+	labelFace font.Face
+	// faceMu font.Face eşzamanlı kullanıma uygun değil (glyph önbelleği)
+	faceMu sync.Mutex
+)
+
+// face etiket fontunu ilk kullanımda yükler; gömülü font okunamazsa ASCII basicfont'a düşer
+func face() font.Face {
+	faceOnce.Do(func() {
+		labelFace = basicfont.Face7x13
+		parsed, err := opentype.Parse(gomono.TTF)
+		if err != nil {
+			return
+		}
+		if f, err := opentype.NewFace(parsed, &opentype.FaceOptions{Size: labelFontSize, DPI: 72, Hinting: font.HintingFull}); err == nil {
+			labelFace = f
+		}
+	})
+	return labelFace
+}
+
+// TextWidth metnin kaplayacağı piksel genişliği
+func TextWidth(text string) int {
+	faceMu.Lock()
+	defer faceMu.Unlock()
+	return font.MeasureString(face(), text).Ceil()
+}
+
+// LineHeight bir satırın satır arası boşluk dahil piksel yüksekliği
+func LineHeight() int {
+	faceMu.Lock()
+	defer faceMu.Unlock()
+	return face().Metrics().Height.Ceil() + 1
+}
+
+// DrawText metni (x, y) sol üst köşesinden başlayarak çizer; görüntü dışına taşan kısım kırpılır
+func DrawText(dst *image.RGBA, x, y int, text string, c color.Color) {
+	faceMu.Lock()
+	defer faceMu.Unlock()
+
+	f := face()
+	d := &font.Drawer{
+		Dst:  dst,
+		Src:  image.NewUniform(c),
+		Face: f,
+		Dot:  fixed.P(x, y+f.Metrics().Ascent.Ceil()),
+	}
+	d.DrawString(text)
+}
+
+// FitText metni maxWidth piksele sığacak şekilde satırlara böler; maxLines aşılırsa son satır ".." ile kesilir
+func FitText(text string, maxWidth, maxLines int) []string {
+	if maxWidth <= 0 || maxLines < 1 {
+		return nil
+	}
+
+	faceMu.Lock()
+	defer faceMu.Unlock()
+	f := face()
+	limit := fixed.I(maxWidth)
+	ellipsis := font.MeasureString(f, "..")
+
+	runes := []rune(text)
+	var lines []string
+	for len(runes) > 0 && len(lines) < maxLines {
+		last := len(lines) == maxLines-1
+		if font.MeasureString(f, string(runes)) <= limit {
+			lines = append(lines, string(runes))
+			break
+		}
+
+		// Satıra sığan en uzun önek (son satırda ".." için yer bırakılır)
+		room := limit
+		if last {
+			room -= ellipsis
+		}
+		n, width := 0, fixed.Int26_6(0)
+		for _, r := range runes {
+			adv, ok := f.GlyphAdvance(r)
+			if !ok {
+				adv, _ = f.GlyphAdvance('?')
+			}
+			if width+adv > room {
+				break
+			}
+			width += adv
+			n++
+		}
+
+		if last {
+			lines = append(lines, string(runes[:n])+"..")
+			break
+		}
+		n = max(n, 1) // Tek karakter bile sığmıyorsa sonsuz döngüye girme
+		lines = append(lines, string(runes[:n]))
+		runes = runes[n:]
+	}
+	return lines
+}
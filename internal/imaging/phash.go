@@ -0,0 +1,148 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"math"
+	"math/bits"
+	"sort"
+)
+
+// flatStdDev bu değerin altındaki parlaklık sapmasına sahip görüntüler (boş/beyaz sayfa) hashlenmez
+const flatStdDev = 3.0
+
+// Decode bellekteki PNG/JPEG görüntüyü çözer
+func Decode(data []byte) (image.Image, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	return img, err
+}
+
+// DHash fark hash'i: 9x8 gri görüntüde yan yana piksellerin parlaklık karşılaştırması.
+// Tam sayfa görüntülerin uzunluğu sonucu bozmasın diye sadece üst kare bölge kullanılır.
+// Düz (tek renk) görüntülerde false döner.
+func DHash(img image.Image) (uint64, bool) {
+	gray := grayscale(aboveFold(img), 9, 8)
+	if isFlat(gray) {
+		return 0, false
+	}
+
+	var hash uint64
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			hash <<= 1
+			if gray[y*9+x] < gray[y*9+x+1] {
+				hash |= 1
+			}
+		}
+	}
+	return hash, true
+}
+
+// PHash algısal hash: 32x32 gri görüntünün DCT'sinin sol üst 8x8 düşük frekans katsayıları
+// medyandan büyükse 1. Sıkıştırma, küçük renk ve boyut farklarına dayanıklıdır.
+func PHash(img image.Image) (uint64, bool) {
+	const size = 32
+	gray := grayscale(aboveFold(img), size, size)
+	if isFlat(gray) {
+		return 0, false
+	}
+
+	// 2 boyutlu DCT-II (sadece ihtiyaç duyulan 8x8 katsayı)
+	var coeffs [64]float64
+	for v := 0; v < 8; v++ {
+		for u := 0; u < 8; u++ {
+			var sum float64
+			for y := 0; y < size; y++ {
+				cy := math.Cos(float64(2*y+1) * float64(v) * math.Pi / (2 * size))
+				for x := 0; x < size; x++ {
+					sum += gray[y*size+x] * cy * math.Cos(float64(2*x+1)*float64(u)*math.Pi/(2*size))
+				}
+			}
+			coeffs[v*8+u] = sum
+		}
+	}
+
+	// DC bileşeni (ortalama parlaklık) medyana katılmaz
+	sorted := append([]float64(nil), coeffs[1:]...)
+	sort.Float64s(sorted)
+	median := sorted[len(sorted)/2]
+
+	var hash uint64
+	for i, c := range coeffs {
+		if i > 0 && c > median {
+			hash |= 1 << uint(63-i)
+		}
+	}
+	return hash, true
+}
+
+// Hamming iki hash arasındaki farklı bit sayısı
+func Hamming(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// aboveFold görüntünün üstten genişliği kadar (kare) bölgesini döndürür
+func aboveFold(img image.Image) image.Image {
+	b := img.Bounds()
+	if b.Dy() <= b.Dx() {
+		return img
+	}
+	if sub, ok := img.(interface {
+		SubImage(image.Rectangle) image.Image
+	}); ok {
+		return sub.SubImage(image.Rect(b.Min.X, b.Min.Y, b.Max.X, b.Min.Y+b.Dx()))
+	}
+	return img
+}
+
+// grayscale görüntüyü kutu filtresiyle width x height gri değerlere (0-255) indirger
+func grayscale(img image.Image, width, height int) []float64 {
+	b := img.Bounds()
+	out := make([]float64, width*height)
+	if b.Dx() == 0 || b.Dy() == 0 {
+		return out
+	}
+
+	sx := float64(b.Dx()) / float64(width)
+	sy := float64(b.Dy()) / float64(height)
+	// Büyük görüntülerde her hücreden en fazla 16x16 örnek alınır
+	stepX := max(1, int(sx/16))
+	stepY := max(1, int(sy/16))
+
+	for y := 0; y < height; y++ {
+		y0 := b.Min.Y + int(float64(y)*sy)
+		y1 := max(y0+1, b.Min.Y+int(float64(y+1)*sy))
+		for x := 0; x < width; x++ {
+			x0 := b.Min.X + int(float64(x)*sx)
+			x1 := max(x0+1, b.Min.X+int(float64(x+1)*sx))
+
+			var sum float64
+			n := 0
+			for py := y0; py < y1 && py < b.Max.Y; py += stepY {
+				for px := x0; px < x1 && px < b.Max.X; px += stepX {
+					r, g, bl, _ := img.At(px, py).RGBA()
+					sum += (0.299*float64(r) + 0.587*float64(g) + 0.114*float64(bl)) / 257
+					n++
+				}
+			}
+			if n > 0 {
+				out[y*width+x] = sum / float64(n)
+			}
+		}
+	}
+	return out
+}
+
+func isFlat(gray []float64) bool {
+	var mean float64
+	for _, v := range gray {
+		mean += v
+	}
+	mean /= float64(len(gray))
+
+	var variance float64
+	for _, v := range gray {
+		variance += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(variance/float64(len(gray))) < flatStdDev
+}
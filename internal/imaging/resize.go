@@ -0,0 +1,85 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png" // Ekran görüntüleri PNG
+	"os"
+)
+
+// Load PNG/JPEG dosyasını çözer
+func Load(path string) (image.Image, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	return img, err
+}
+
+// Thumbnail görüntüyü verilen genişliğe küçültür; maxHeight > 0 ise uzun sayfaların sadece üst kısmı alınır
+func Thumbnail(src image.Image, width, maxHeight int) image.Image {
+	b := src.Bounds()
+	if b.Dx() == 0 || b.Dy() == 0 || width <= 0 {
+		return src
+	}
+
+	scale := float64(b.Dx()) / float64(width)
+	height := int(float64(b.Dy()) / scale)
+	if height < 1 {
+		height = 1
+	}
+	if maxHeight > 0 && height > maxHeight {
+		height = maxHeight
+	}
+	return Resize(src, width, height, scale)
+}
+
+// Resize kutu filtresi (alan ortalaması) ile ölçekler; scale kaynak pikselin hedefe oranıdır
+func Resize(src image.Image, width, height int, scale float64) *image.RGBA {
+	b := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		sy0 := b.Min.Y + int(float64(y)*scale)
+		sy1 := min(b.Max.Y, b.Min.Y+int(float64(y+1)*scale))
+		if sy1 <= sy0 {
+			sy1 = min(b.Max.Y, sy0+1)
+		}
+		for x := 0; x < width; x++ {
+			sx0 := b.Min.X + int(float64(x)*scale)
+			sx1 := min(b.Max.X, b.Min.X+int(float64(x+1)*scale))
+			if sx1 <= sx0 {
+				sx1 = min(b.Max.X, sx0+1)
+			}
+
+			var r, g, bl, a, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r += uint64(cr)
+					g += uint64(cg)
+					bl += uint64(cb)
+					a += uint64(ca)
+					n++
+				}
+			}
+			if n == 0 {
+				continue
+			}
+			dst.Set(x, y, color.RGBA64{uint16(r / n), uint16(g / n), uint16(bl / n), uint16(a / n)})
+		}
+	}
+	return dst
+}
+
+// EncodeJPEG görüntüyü JPEG baytlarına çevirir
+func EncodeJPEG(img image.Image, quality int) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,70 @@
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// Tile kontak sayfasındaki tek bir küçük resim ve altındaki etiket satırları
+type Tile struct {
+	Image image.Image
+	Lines []string
+}
+
+const (
+	sheetPadding = 8
+	labelLines   = 3 // URL için 2, etiket için 1 satır
+)
+
+var (
+	sheetBackground = color.RGBA{0x1e, 0x1e, 0x1e, 0xff}
+	tileBackground  = color.RGBA{0x2d, 0x2d, 0x2d, 0xff}
+	labelColor      = color.RGBA{0xe0, 0xe0, 0xe0, 0xff}
+)
+
+// ContactSheet küçük resimleri columns sütunlu bir ızgaraya dizer.
+// Her hücre tileWidth x tileHeight görüntü alanı ve altında etiket satırlarından oluşur.
+func ContactSheet(tiles []Tile, columns, tileWidth, tileHeight int) *image.RGBA {
+	if len(tiles) == 0 || columns <= 0 {
+		return nil
+	}
+	columns = min(columns, len(tiles))
+	rows := (len(tiles) + columns - 1) / columns
+
+	labelHeight := labelLines*LineHeight() + sheetPadding/2
+	cellW := tileWidth + sheetPadding
+	cellH := tileHeight + labelHeight + sheetPadding
+
+	sheet := image.NewRGBA(image.Rect(0, 0, columns*cellW+sheetPadding, rows*cellH+sheetPadding))
+	draw.Draw(sheet, sheet.Bounds(), &image.Uniform{sheetBackground}, image.Point{}, draw.Src)
+
+	for i, t := range tiles {
+		x := sheetPadding + (i%columns)*cellW
+		y := sheetPadding + (i/columns)*cellH
+
+		cell := image.Rect(x, y, x+tileWidth, y+tileHeight+labelHeight)
+		draw.Draw(sheet, cell, &image.Uniform{tileBackground}, image.Point{}, draw.Src)
+		if t.Image != nil {
+			// Küçük resim hücreden büyükse üst kısmı alınır
+			area := image.Rect(x, y, x+tileWidth, y+tileHeight)
+			draw.Draw(sheet, area, t.Image, t.Image.Bounds().Min, draw.Src)
+		}
+
+		ly := y + tileHeight + sheetPadding/2
+		for _, line := range t.Lines {
+			DrawText(sheet, x+2, ly, line, labelColor)
+			ly += LineHeight()
+		}
+	}
+	return sheet
+}
+
+// LabelLines URL'i (en fazla 2 satır) ve etiketi hücre genişliğine sığacak satırlara böler
+func LabelLines(url, tag string, tileWidth int) []string {
+	lines := FitText(url, tileWidth-4, labelLines-1)
+	if tag != "" {
+		lines = append(lines, FitText(tag, tileWidth-4, 1)...)
+	}
+	return lines
+}
@@ -0,0 +1,127 @@
+package intel
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// Varlık tipleri
+const (
+	EntityEmail    = "email"
+	EntityJabber   = "jabber"
+	EntityTelegram = "telegram"
+	EntityBTC      = "btc"
+	EntityXMR      = "xmr"
+	EntityETH      = "eth"
+)
+
+// Entity sayfadan çıkarılan iletişim bilgisi veya kripto cüzdan adresi
+type Entity struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// IsWallet varlığın kripto cüzdan olup olmadığını söyler
+func (e Entity) IsWallet() bool {
+	return e.Type == EntityBTC || e.Type == EntityXMR || e.Type == EntityETH
+}
+
+var (
+	reEmail    = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,24}`)
+	reJabber   = regexp.MustCompile(`(?i)(?:jabber|xmpp|jid)\s*(?::|-|=)?\s*([a-z0-9._%+-]+@[a-z0-9.-]+\.[a-z]{2,24})`)
+	reTelegram = regexp.MustCompile(`(?i)(?:t\.me/|telegram\.me/|telegram\s*:?\s*@)([a-z0-9_]{5,32})`)
+	reBTC      = regexp.MustCompile(`\b(?:[13][a-km-zA-HJ-NP-Z1-9]{25,34}|bc1[02-9ac-hj-np-z]{25,87})\b`)
+	reXMR      = regexp.MustCompile(`\b[48][0-9AB][1-9A-HJ-NP-Za-km-z]{93}\b`)
+	reETH      = regexp.MustCompile(`\b0x[a-fA-F0-9]{40}\b`)
+)
+
+// ignoredEmailSuffixes e-posta gibi görünen dosya adları (logo@2x.png vb.)
+var ignoredEmailSuffixes = []string{"png", "jpg", "jpeg", "gif", "svg", "webp", "css", "js"}
+
+// ExtractEntities sayfa kaynağından iletişim bilgilerini ve cüzdan adreslerini çıkarır
+func ExtractEntities(htmlContent string) []Entity {
+	var entities []Entity
+	seen := map[string]bool{}
+
+	add := func(kind, value string) {
+		key := kind + "|" + strings.ToLower(value)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		entities = append(entities, Entity{Type: kind, Value: value})
+	}
+
+	// Jabber adresleri e-posta formatında olduğu için önce onları ayır
+	jabber := map[string]bool{}
+	for _, m := range reJabber.FindAllStringSubmatch(htmlContent, -1) {
+		id := strings.ToLower(m[1])
+		jabber[id] = true
+		add(EntityJabber, id)
+	}
+
+	for _, m := range reEmail.FindAllString(htmlContent, -1) {
+		email := strings.ToLower(m)
+		if jabber[email] || hasSuffix(email, ignoredEmailSuffixes) {
+			continue
+		}
+		add(EntityEmail, email)
+	}
+
+	for _, m := range reTelegram.FindAllStringSubmatch(htmlContent, -1) {
+		add(EntityTelegram, m[1])
+	}
+
+	for _, m := range reBTC.FindAllString(htmlContent, -1) {
+		if strings.HasPrefix(m, "bc1") || validBase58Check(m) {
+			add(EntityBTC, m)
+		}
+	}
+	for _, m := range reXMR.FindAllString(htmlContent, -1) {
+		add(EntityXMR, m)
+	}
+	for _, m := range reETH.FindAllString(htmlContent, -1) {
+		add(EntityETH, m)
+	}
+
+	return entities
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// validBase58Check eski tip BTC adreslerinin sağlama toplamını doğrular (rastgele eşleşmeleri eler)
+func validBase58Check(addr string) bool {
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	for _, c := range addr {
+		idx := strings.IndexRune(base58Alphabet, c)
+		if idx < 0 {
+			return false
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(idx)))
+	}
+
+	decoded := n.Bytes()
+	// Baştaki '1' karakterleri sıfır baytlarını temsil eder
+	for _, c := range addr {
+		if c != '1' {
+			break
+		}
+		decoded = append([]byte{0}, decoded...)
+	}
+	if len(decoded) != 25 {
+		return false
+	}
+
+	first := sha256.Sum256(decoded[:21])
+	second := sha256.Sum256(first[:])
+	for i := 0; i < 4; i++ {
+		if second[i] != decoded[21+i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,177 @@
+package intel
+
+import (
+	"net"
+	"regexp"
+	"strings"
+)
+
+// Bulgu tipleri
+const (
+	FindingClearnetIP     = "clearnet_ip"
+	FindingPrivateIP      = "private_ip"
+	FindingClearnetDomain = "clearnet_domain"
+	FindingCDN            = "cdn"
+	FindingBucket         = "bucket"
+	FindingAnalytics      = "analytics_id"
+	FindingEmailHeader    = "email_header"
+	FindingServerStatus   = "server_status"
+	FindingStackTrace     = "stack_trace"
+)
+
+// snippetRadius kanıt parçasında eşleşmenin iki yanından alınan karakter sayısı
+const snippetRadius = 40
+
+// maxPerType aynı tipten en fazla kaç bulgu kaydedileceği (gürültüyü sınırlamak için)
+const maxPerType = 50
+
+// Finding operatörün açık ağ altyapısını sızdıran tek bir bulgu
+type Finding struct {
+	Type     string `json:"type"`
+	Value    string `json:"value"`
+	Evidence string `json:"evidence"`
+}
+
+var (
+	reIPv4      = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+	reURLHost   = regexp.MustCompile(`(?i)(?:https?:|["'=(])//([a-z0-9][a-z0-9.-]*\.[a-z]{2,24})(?::\d+)?`) // Şemasız "//" sadece tırnak, = veya ( ardından (JS yorumları eşleşmez)
+	reGA        = regexp.MustCompile(`\b(UA-\d{4,10}-\d{1,4}|G-[A-Z0-9]{6,12}|GTM-[A-Z0-9]{4,10}|AW-\d{6,12})\b`)
+	reYandex    = regexp.MustCompile(`(?:mc\.yandex\.ru/watch/|ym\(\s*|yaCounter)(\d{5,12})`)
+	reMailHdr   = regexp.MustCompile(`(?im)^\s*(?:Received: from|X-Originating-IP:|Return-Path:|X-Mailer:|DKIM-Signature:|Message-ID: <)[^\n]*`)
+	reSrvStatus = regexp.MustCompile(`(?i)Apache Server Status for|Server Version: Apache|<h1>Apache Status</h1>|nginx status|Active connections: \d+`)
+	reStack     = regexp.MustCompile(`(?i)(?:PHP (?:Fatal error|Warning|Notice|Parse error)|Fatal error: Uncaught|Traceback \(most recent call last\)|Exception in thread "|at [a-z0-9_.$]+\([A-Za-z0-9_]+\.java:\d+\)|Stack trace:\s*#0|django\.core\.exceptions|Whoops! There was an error|on line <b>\d+</b>)`)
+)
+
+// cdnSuffixes CDN alan adları
+var cdnSuffixes = []string{
+	"cloudfront.net", "cloudflare.com", "cdnjs.cloudflare.com", "jsdelivr.net", "unpkg.com",
+	"akamaihd.net", "akamaized.net", "fastly.net", "googleapis.com", "gstatic.com",
+	"bootstrapcdn.com", "azureedge.net", "b-cdn.net",
+}
+
+// bucketSuffixes bulut depolama (bucket) alan adları
+var bucketSuffixes = []string{
+	"s3.amazonaws.com", "amazonaws.com", "storage.googleapis.com", "blob.core.windows.net",
+	"digitaloceanspaces.com", "r2.dev", "r2.cloudflarestorage.com", "backblazeb2.com", "wasabisys.com",
+}
+
+// ignoredDomains HTML standartlarında geçen ve sızıntı sayılmayan alan adları
+var ignoredDomains = []string{
+	"w3.org", "schema.org", "ogp.me", "purl.org", "xmlns.com", "example.com", "torproject.org",
+}
+
+// DetectOpsecLeaks sayfa kaynağında açık ağ altyapısına dair izleri arar
+func DetectOpsecLeaks(htmlContent string) []Finding {
+	c := newCollector(htmlContent)
+
+	// IP adresleri
+	for _, loc := range reIPv4.FindAllStringIndex(htmlContent, -1) {
+		value := htmlContent[loc[0]:loc[1]]
+		ip := net.ParseIP(value)
+		if ip == nil || ip.IsLoopback() || ip.IsUnspecified() || ip.IsMulticast() || ip.Equal(net.IPv4bcast) {
+			continue
+		}
+		// 1.2.3.4 gibi sürüm numaralarını ele (önünde "v" veya "version" varsa)
+		before := strings.ToLower(htmlContent[max(0, loc[0]-8):loc[0]])
+		if strings.HasSuffix(before, "v") || strings.Contains(before, "version") {
+			continue
+		}
+		if ip.IsPrivate() || ip.IsLinkLocalUnicast() {
+			c.add(FindingPrivateIP, value, loc)
+		} else {
+			c.add(FindingClearnetIP, value, loc)
+		}
+	}
+
+	// Alan adları (CDN ve bucket'lar ayrı sınıflanır)
+	for _, m := range reURLHost.FindAllStringSubmatchIndex(htmlContent, -1) {
+		host := strings.ToLower(htmlContent[m[2]:m[3]])
+		if strings.HasSuffix(host, ".onion") || net.ParseIP(host) != nil || hasSuffix(host, ignoredDomains) {
+			continue
+		}
+		loc := []int{m[0], m[1]}
+		switch {
+		case hasSuffix(host, bucketSuffixes):
+			c.add(FindingBucket, host, loc)
+		case hasSuffix(host, cdnSuffixes):
+			c.add(FindingCDN, host, loc)
+		default:
+			c.add(FindingClearnetDomain, host, loc)
+		}
+	}
+
+	// Analitik / etiket ID'leri
+	for _, m := range reGA.FindAllStringSubmatchIndex(htmlContent, -1) {
+		c.add(FindingAnalytics, htmlContent[m[2]:m[3]], []int{m[0], m[1]})
+	}
+	for _, m := range reYandex.FindAllStringSubmatchIndex(htmlContent, -1) {
+		c.add(FindingAnalytics, "YM-"+htmlContent[m[2]:m[3]], []int{m[0], m[1]})
+	}
+
+	// E-posta başlıkları, server-status ve hata çıktıları
+	for _, loc := range reMailHdr.FindAllStringIndex(htmlContent, -1) {
+		c.add(FindingEmailHeader, strings.TrimSpace(htmlContent[loc[0]:loc[1]]), loc)
+	}
+	for _, loc := range reSrvStatus.FindAllStringIndex(htmlContent, -1) {
+		c.add(FindingServerStatus, htmlContent[loc[0]:loc[1]], loc)
+	}
+	for _, loc := range reStack.FindAllStringIndex(htmlContent, -1) {
+		c.add(FindingStackTrace, htmlContent[loc[0]:loc[1]], loc)
+	}
+
+	return c.findings
+}
+
+// collector bulguları tekilleştirerek toplar
+type collector struct {
+	source   string
+	seen     map[string]bool
+	perType  map[string]int
+	findings []Finding
+}
+
+func newCollector(source string) *collector {
+	return &collector{
+		source:  source,
+		seen:    map[string]bool{},
+		perType: map[string]int{},
+	}
+}
+
+func (c *collector) add(kind, value string, loc []int) {
+	key := kind + "|" + strings.ToLower(value)
+	if c.seen[key] || c.perType[kind] >= maxPerType {
+		return
+	}
+	c.seen[key] = true
+	c.perType[kind]++
+
+	c.findings = append(c.findings, Finding{
+		Type:     kind,
+		Value:    truncate(value, 200),
+		Evidence: snippet(c.source, loc[0], loc[1]),
+	})
+}
+
+// snippet eşleşmenin çevresinden tek satırlık kanıt parçası üretir
+func snippet(source string, start, end int) string {
+	from := max(0, start-snippetRadius)
+	to := min(len(source), end+snippetRadius)
+	return strings.Join(strings.Fields(strings.ToValidUTF8(source[from:to], "")), " ")
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return strings.ToValidUTF8(s[:n], "") + "..."
+}
+
+func hasSuffix(host string, suffixes []string) bool {
+	for _, s := range suffixes {
+		if host == s || strings.HasSuffix(host, "."+s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+package leaksite
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Extract sayfaya uyan şablonu bulur ve kurban kayıtlarını çıkarır
+func Extract(htmlContent string, pageURL string) []Victim {
+	if len(GlobalTemplates.Templates) == 0 {
+		return nil
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+	if err != nil {
+		return nil
+	}
+
+	tmpl := matchTemplate(doc, pageURL)
+	if tmpl == nil {
+		return nil
+	}
+
+	var victims []Victim
+	doc.Find(tmpl.Item).Each(func(i int, item *goquery.Selection) {
+		v := Victim{
+			Group:     tmpl.Name,
+			Name:      readField(item, tmpl.Fields.Name),
+			Domain:    readField(item, tmpl.Fields.Domain),
+			Country:   readField(item, tmpl.Fields.Country),
+			PostDate:  readField(item, tmpl.Fields.PostDate),
+			Deadline:  readField(item, tmpl.Fields.Deadline),
+			DataSize:  readField(item, tmpl.Fields.DataSize),
+			Status:    readField(item, tmpl.Fields.Status),
+			SourceURL: pageURL,
+		}
+
+		// İsmi olmayan kayıt büyük ihtimalle boş bir kart veya reklam
+		if v.Name == "" {
+			return
+		}
+		victims = append(victims, v)
+	})
+
+	return victims
+}
+
+// matchTemplate önce host, sonra sayfa parmak izine göre şablon seçer
+func matchTemplate(doc *goquery.Document, pageURL string) *Template {
+	host := hostOf(pageURL)
+
+	for i := range GlobalTemplates.Templates {
+		t := &GlobalTemplates.Templates[i]
+		for _, h := range t.Hosts {
+			if strings.EqualFold(strings.TrimSpace(h), host) {
+				return t
+			}
+		}
+	}
+
+	title := strings.ToLower(doc.Find("title").Text())
+	for i := range GlobalTemplates.Templates {
+		t := &GlobalTemplates.Templates[i]
+		if fingerprintMatches(t.Fingerprint, doc, title) {
+			return t
+		}
+	}
+
+	return nil
+}
+
+// fingerprintMatches tanımlı tüm koşullar sağlanıyorsa true döner
+func fingerprintMatches(fp Fingerprint, doc *goquery.Document, lowerTitle string) bool {
+	// Boş parmak izi her sayfaya uymasın
+	if fp.TitleContains == "" && len(fp.Selectors) == 0 {
+		return false
+	}
+
+	if fp.TitleContains != "" && !strings.Contains(lowerTitle, strings.ToLower(fp.TitleContains)) {
+		return false
+	}
+
+	for _, sel := range fp.Selectors {
+		if doc.Find(sel).Length() == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// readField item içinden tek bir alanı okur
+func readField(item *goquery.Selection, rule FieldRule) string {
+	if rule.Selector == "" && rule.Attr == "" && rule.Regex == "" {
+		return ""
+	}
+
+	sel := item
+	if rule.Selector != "" {
+		sel = item.Find(rule.Selector).First()
+		if sel.Length() == 0 {
+			return ""
+		}
+	}
+
+	var value string
+	if rule.Attr != "" {
+		value, _ = sel.Attr(rule.Attr)
+	} else {
+		value = sel.Text()
+	}
+	value = strings.Join(strings.Fields(value), " ")
+
+	// Regex varsa ilk grubu (yoksa tüm eşleşmeyi) al
+	if rule.Regex != "" {
+		re := regexCache[rule.Regex]
+		if re == nil {
+			return ""
+		}
+		m := re.FindStringSubmatch(value)
+		switch {
+		case m == nil:
+			return ""
+		case len(m) > 1:
+			value = strings.TrimSpace(m[1])
+		default:
+			value = strings.TrimSpace(m[0])
+		}
+	}
+
+	return value
+}
+
+// hostOf URL'den host kısmını çıkarır (şema olmasa da çalışır)
+func hostOf(rawURL string) string {
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "http://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
@@ -0,0 +1,53 @@
+package leaksite
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+var GlobalTemplates TemplateConfig
+
+// compiled regex önbelleği (her sayfada yeniden derlememek için)
+var regexCache = map[string]*regexp.Regexp{}
+
+// LoadTemplates belirtilen dosya yolundan çıkarma şablonlarını yükler
+func LoadTemplates(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("şablon dosyası okunamadı: %v", err)
+	}
+
+	var cfg TemplateConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return fmt.Errorf("YAML parse hatası: %v", err)
+	}
+
+	cache := map[string]*regexp.Regexp{}
+	for _, t := range cfg.Templates {
+		if t.Item == "" {
+			return fmt.Errorf("%s şablonunda 'item' seçicisi eksik", t.ID)
+		}
+		for _, rule := range t.Fields.all() {
+			if rule.Regex == "" {
+				continue
+			}
+			re, err := regexp.Compile(rule.Regex)
+			if err != nil {
+				return fmt.Errorf("%s şablonunda hatalı regex (%s): %v", t.ID, rule.Regex, err)
+			}
+			cache[rule.Regex] = re
+		}
+	}
+
+	GlobalTemplates = cfg
+	regexCache = cache
+	return nil
+}
+
+// all alan kurallarını sırayla döndürür
+func (f FieldRules) all() []FieldRule {
+	return []FieldRule{f.Name, f.Domain, f.Country, f.PostDate, f.Deadline, f.DataSize, f.Status}
+}
@@ -0,0 +1,50 @@
+package leaksite
+
+type TemplateConfig struct {
+	Templates []Template `yaml:"templates"`
+}
+
+type Template struct {
+	ID          string      `yaml:"id"`
+	Name        string      `yaml:"name"`
+	Hosts       []string    `yaml:"hosts"`
+	Fingerprint Fingerprint `yaml:"fingerprint"`
+	Item        string      `yaml:"item"`
+	Fields      FieldRules  `yaml:"fields"`
+}
+
+// Fingerprint host bilinmediğinde (yeni ayna vb.) sayfayı tanımak için kullanılır
+type Fingerprint struct {
+	TitleContains string   `yaml:"title_contains"`
+	Selectors     []string `yaml:"selectors"`
+}
+
+type FieldRules struct {
+	Name     FieldRule `yaml:"name"`
+	Domain   FieldRule `yaml:"domain"`
+	Country  FieldRule `yaml:"country"`
+	PostDate FieldRule `yaml:"post_date"`
+	Deadline FieldRule `yaml:"deadline"`
+	DataSize FieldRule `yaml:"data_size"`
+	Status   FieldRule `yaml:"status"`
+}
+
+// FieldRule item içindeki bir alanın nasıl okunacağını tanımlar
+type FieldRule struct {
+	Selector string `yaml:"selector"`
+	Attr     string `yaml:"attr"`
+	Regex    string `yaml:"regex"`
+}
+
+// Victim sızıntı sitesinden çıkarılan tek bir kurban kaydı
+type Victim struct {
+	Group     string `json:"group"`
+	Name      string `json:"name"`
+	Domain    string `json:"domain,omitempty"`
+	Country   string `json:"country,omitempty"`
+	PostDate  string `json:"post_date,omitempty"`
+	Deadline  string `json:"deadline,omitempty"`
+	DataSize  string `json:"data_size,omitempty"`
+	Status    string `json:"status,omitempty"`
+	SourceURL string `json:"source_url"`
+}
@@ -0,0 +1,92 @@
+package network
+
+import (
+	"bytes"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"strings"
+	"sync"
+)
+
+// maxHeaderCapture ham header yakalamada tutulacak en fazla bayt
+const maxHeaderCapture = 32 * 1024
+
+// captureConn bağlantıdan okunan ilk baytları (response header'ları) ham haliyle saklar.
+// net/http header'ları map'e çevirdiği için sunucunun gönderdiği sıra ancak bu şekilde korunur.
+type captureConn struct {
+	net.Conn
+	mu     sync.Mutex
+	active bool
+	buf    bytes.Buffer
+}
+
+func (c *captureConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+	if n > 0 {
+		c.mu.Lock()
+		if c.active {
+			c.buf.Write(p[:n])
+			if bytes.Contains(c.buf.Bytes(), []byte("\r\n\r\n")) || c.buf.Len() > maxHeaderCapture {
+				c.active = false
+			}
+		}
+		c.mu.Unlock()
+	}
+	return n, err
+}
+
+// start yeni bir istek için yakalamayı sıfırlar
+func (c *captureConn) start() {
+	c.mu.Lock()
+	c.buf.Reset()
+	c.active = true
+	c.mu.Unlock()
+}
+
+// headerNames yakalanan ham yanıttaki header isimlerini geliş sırasıyla döndürür
+func (c *captureConn) headerNames() []string {
+	c.mu.Lock()
+	raw := c.buf.String()
+	c.mu.Unlock()
+
+	if idx := strings.Index(raw, "\r\n\r\n"); idx >= 0 {
+		raw = raw[:idx]
+	}
+
+	lines := strings.Split(raw, "\r\n")
+	if len(lines) < 2 || !strings.HasPrefix(lines[0], "HTTP/") {
+		return nil
+	}
+
+	var names []string
+	for _, line := range lines[1:] {
+		if name, _, ok := strings.Cut(line, ":"); ok {
+			names = append(names, strings.TrimSpace(name))
+		}
+	}
+	return names
+}
+
+// TraceHeaderOrder isteğe bağlantı takibi ekler ve yanıt geldikten sonra
+// header sırasını döndüren bir fonksiyon verir. Sıra okunamazsa (TLS vb.) nil döner.
+func TraceHeaderOrder(req *http.Request) (*http.Request, func() []string) {
+	var conn *captureConn
+
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if c, ok := info.Conn.(*captureConn); ok {
+				c.start()
+				conn = c
+			}
+		},
+	}
+
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+	return req, func() []string {
+		if conn == nil {
+			return nil
+		}
+		return conn.headerNames()
+	}
+}
@@ -0,0 +1,186 @@
+package network
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/proxy"
+)
+
+// SOCKS5 yanıt kodları (RFC 1928)
+const (
+	socksSucceeded      = 0x00
+	socksGeneralFailure = 0x01
+	socksNotAllowed     = 0x02
+	socksHostUnreach    = 0x04
+	socksCmdUnsupported = 0x07
+)
+
+// handshakeTimeout istemcinin SOCKS el sıkışmasını tamamlaması için süre
+const handshakeTimeout = 30 * time.Second
+
+// OnionProxy tarayıcı ile Tor arasına giren yerel SOCKS5 sunucusu. Sadece .onion adreslerine
+// bağlantıya izin verir; açık ağ alan adları ve IP adresleri proxy katmanında reddedilir.
+// Bağlantı türünden bağımsız çalıştığı için WebSocket, service worker ve sayfa dışı istekleri de kapsar.
+type OnionProxy struct {
+	listener net.Listener
+	dialer   proxy.Dialer
+	onBlock  func(host string) // Reddedilen her bağlantı için (nil olabilir)
+	wg       sync.WaitGroup
+}
+
+// StartOnionProxy 127.0.0.1 üzerinde rastgele bir portta filtreleyici proxy'yi başlatır
+func StartOnionProxy(torAddr string, onBlock func(host string)) (*OnionProxy, error) {
+	dialer, err := proxy.SOCKS5("tcp", torAddr, nil, proxy.Direct)
+	if err != nil {
+		return nil, err
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	p := &OnionProxy{listener: ln, dialer: dialer, onBlock: onBlock}
+	p.wg.Add(1)
+	go p.serve()
+	return p, nil
+}
+
+// Addr proxy'nin dinlediği "127.0.0.1:port" adresi
+func (p *OnionProxy) Addr() string {
+	return p.listener.Addr().String()
+}
+
+// Close yeni bağlantı kabulünü durdurur (açık tüneller kendi bağlantıları kapanınca biter)
+func (p *OnionProxy) Close() error {
+	err := p.listener.Close()
+	p.wg.Wait()
+	return err
+}
+
+func (p *OnionProxy) serve() {
+	defer p.wg.Done()
+	for {
+		conn, err := p.listener.Accept()
+		if err != nil {
+			return
+		}
+		go p.handle(conn)
+	}
+}
+
+func (p *OnionProxy) handle(conn net.Conn) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(handshakeTimeout))
+
+	host, port, err := readSocksRequest(conn)
+	if err != nil {
+		return
+	}
+
+	if !allowedHost(host) {
+		writeSocksReply(conn, socksNotAllowed)
+		if p.onBlock != nil {
+			p.onBlock(host)
+		}
+		return
+	}
+
+	upstream, err := p.dialer.Dial("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		writeSocksReply(conn, socksHostUnreach)
+		return
+	}
+	defer upstream.Close()
+
+	if err := writeSocksReply(conn, socksSucceeded); err != nil {
+		return
+	}
+	conn.SetDeadline(time.Time{})
+
+	done := make(chan struct{}, 2)
+	go func() { io.Copy(upstream, conn); done <- struct{}{} }()
+	go func() { io.Copy(conn, upstream); done <- struct{}{} }()
+	<-done
+}
+
+// allowedHost sadece alan adı olarak gelen .onion hedeflerine izin verir. IP ile gelen istek açık ağdır;
+// sondaki noktalı ("x.onion.") ve boş etiketli (".onion") adlar da reddedilir.
+func allowedHost(host string) bool {
+	if net.ParseIP(host) != nil {
+		return false
+	}
+	host = strings.ToLower(host)
+	return strings.HasSuffix(host, ".onion") && len(host) > len(".onion")
+}
+
+// readSocksRequest kimlik doğrulamasız el sıkışmayı yapar ve CONNECT hedefini okur
+func readSocksRequest(conn net.Conn) (string, int, error) {
+	buf := make([]byte, 256)
+
+	// Selamlama: VER NMETHODS METHODS...
+	if _, err := io.ReadFull(conn, buf[:2]); err != nil {
+		return "", 0, err
+	}
+	if buf[0] != 0x05 {
+		return "", 0, errors.New("SOCKS5 değil")
+	}
+	if _, err := io.ReadFull(conn, buf[:buf[1]]); err != nil {
+		return "", 0, err
+	}
+	if _, err := conn.Write([]byte{0x05, 0x00}); err != nil {
+		return "", 0, err
+	}
+
+	// İstek: VER CMD RSV ATYP (komut, istek tamamen okunduktan sonra kontrol edilir)
+	if _, err := io.ReadFull(conn, buf[:4]); err != nil {
+		return "", 0, err
+	}
+	cmd := buf[1]
+
+	var host string
+	switch buf[3] {
+	case 0x01: // IPv4
+		if _, err := io.ReadFull(conn, buf[:4]); err != nil {
+			return "", 0, err
+		}
+		host = net.IP(buf[:4]).String()
+	case 0x03: // Alan adı
+		if _, err := io.ReadFull(conn, buf[:1]); err != nil {
+			return "", 0, err
+		}
+		n := int(buf[0])
+		if _, err := io.ReadFull(conn, buf[:n]); err != nil {
+			return "", 0, err
+		}
+		host = string(buf[:n])
+	case 0x04: // IPv6
+		if _, err := io.ReadFull(conn, buf[:16]); err != nil {
+			return "", 0, err
+		}
+		host = net.IP(buf[:16]).String()
+	default:
+		writeSocksReply(conn, socksGeneralFailure)
+		return "", 0, errors.New("bilinmeyen adres türü")
+	}
+
+	if _, err := io.ReadFull(conn, buf[:2]); err != nil {
+		return "", 0, err
+	}
+	if cmd != 0x01 {
+		writeSocksReply(conn, socksCmdUnsupported)
+		return "", 0, errors.New("sadece CONNECT destekleniyor")
+	}
+	return host, int(binary.BigEndian.Uint16(buf[:2])), nil
+}
+
+func writeSocksReply(conn net.Conn, code byte) error {
+	_, err := conn.Write([]byte{0x05, code, 0x00, 0x01, 0, 0, 0, 0, 0, 0})
+	return err
+}
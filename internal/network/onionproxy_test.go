@@ -0,0 +1,127 @@
+package network
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+// fakeDialer Tor yerine geçer: çağrılan adresi kaydeder ve bellek içi bir bağlantı döndürür
+type fakeDialer struct {
+	dialed []string
+}
+
+func (d *fakeDialer) Dial(network, addr string) (net.Conn, error) {
+	d.dialed = append(d.dialed, addr)
+	server, client := net.Pipe()
+	go func() {
+		io.Copy(io.Discard, server)
+		server.Close()
+	}()
+	return client, nil
+}
+
+// socksRequest CONNECT (veya verilen komut) isteğini SOCKS5 biçiminde kurar
+func socksRequest(cmd, atyp byte, addr []byte, port uint16) []byte {
+	req := []byte{0x05, cmd, 0x00, atyp}
+	if atyp == 0x03 {
+		req = append(req, byte(len(addr)))
+	}
+	req = append(req, addr...)
+	return binary.BigEndian.AppendUint16(req, port)
+}
+
+func TestOnionProxyHandle(t *testing.T) {
+	tests := []struct {
+		name    string
+		request []byte
+		reply   byte
+		dial    string // boşsa Tor'a hiç bağlanılmamalı
+	}{
+		{"onion alan adı", socksRequest(0x01, 0x03, []byte("abcdefghijklmnop.onion"), 80), socksSucceeded, "abcdefghijklmnop.onion:80"},
+		{"büyük harfli onion", socksRequest(0x01, 0x03, []byte("ABCDEFGHIJKLMNOP.ONION"), 443), socksSucceeded, "ABCDEFGHIJKLMNOP.ONION:443"},
+		{"açık ağ alan adı", socksRequest(0x01, 0x03, []byte("example.com"), 443), socksNotAllowed, ""},
+		{"onion ile biten açık ağ adı", socksRequest(0x01, 0x03, []byte("onion.example.com"), 80), socksNotAllowed, ""},
+		{"sonda nokta", socksRequest(0x01, 0x03, []byte("abcdefghijklmnop.onion."), 80), socksNotAllowed, ""},
+		{"boş etiket", socksRequest(0x01, 0x03, []byte(".onion"), 80), socksNotAllowed, ""},
+		{"alan adı olarak IP", socksRequest(0x01, 0x03, []byte("10.0.0.1"), 80), socksNotAllowed, ""},
+		{"IPv4", socksRequest(0x01, 0x01, net.ParseIP("93.184.216.34").To4(), 80), socksNotAllowed, ""},
+		{"IPv6", socksRequest(0x01, 0x04, net.ParseIP("2606:2800:220:1::1"), 80), socksNotAllowed, ""},
+		{"BIND", socksRequest(0x02, 0x03, []byte("abcdefghijklmnop.onion"), 80), socksCmdUnsupported, ""},
+		{"UDP ASSOCIATE", socksRequest(0x03, 0x03, []byte("abcdefghijklmnop.onion"), 80), socksCmdUnsupported, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dialer := &fakeDialer{}
+			var blocked []string
+			p := &OnionProxy{dialer: dialer, onBlock: func(host string) { blocked = append(blocked, host) }}
+
+			client, server := net.Pipe()
+			done := make(chan struct{})
+			go func() {
+				p.handle(server)
+				close(done)
+			}()
+			client.SetDeadline(time.Now().Add(5 * time.Second))
+
+			// Selamlama: kimlik doğrulamasız yöntem seçilmeli
+			if _, err := client.Write([]byte{0x05, 0x01, 0x00}); err != nil {
+				t.Fatal(err)
+			}
+			method := make([]byte, 2)
+			if _, err := io.ReadFull(client, method); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(method, []byte{0x05, 0x00}) {
+				t.Fatalf("yöntem yanıtı %v", method)
+			}
+
+			if _, err := client.Write(tt.request); err != nil {
+				t.Fatal(err)
+			}
+			reply := make([]byte, 10)
+			if _, err := io.ReadFull(client, reply); err != nil {
+				t.Fatal(err)
+			}
+			if reply[1] != tt.reply {
+				t.Errorf("yanıt kodu %#x, beklenen %#x", reply[1], tt.reply)
+			}
+
+			client.Close()
+			<-done
+
+			switch {
+			case tt.dial == "" && len(dialer.dialed) > 0:
+				t.Errorf("Tor'a bağlanılmamalıydı: %v", dialer.dialed)
+			case tt.dial != "" && (len(dialer.dialed) != 1 || dialer.dialed[0] != tt.dial):
+				t.Errorf("Tor bağlantısı %v, beklenen %s", dialer.dialed, tt.dial)
+			}
+			if tt.reply == socksNotAllowed && len(blocked) != 1 {
+				t.Errorf("onBlock çağrılmadı")
+			}
+		})
+	}
+}
+
+func TestOnionProxyRejectsNonSOCKS5(t *testing.T) {
+	p := &OnionProxy{dialer: &fakeDialer{}}
+	client, server := net.Pipe()
+	done := make(chan struct{})
+	go func() {
+		p.handle(server)
+		close(done)
+	}()
+	client.SetDeadline(time.Now().Add(5 * time.Second))
+
+	// SOCKS4 selamlaması bağlantıyı yanıtsız kapatmalı
+	client.Write([]byte{0x04, 0x01})
+	if _, err := client.Read(make([]byte, 1)); !errors.Is(err, io.EOF) {
+		t.Errorf("bağlantı kapatılmadı: %v", err)
+	}
+	<-done
+}
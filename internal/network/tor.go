@@ -3,6 +3,7 @@ package network
 import (
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -50,7 +51,14 @@ func NewTorClient() (*http.Client, string, error) {
 
 	// Çeviriciyi kullanan bir transport oluşturur
 	transport := &http.Transport{
-		Dial: dialer.Dial,
+		// Header sırası parmak izi için bağlantıyı sarmala
+		Dial: func(network, addr string) (net.Conn, error) {
+			conn, err := dialer.Dial(network, addr)
+			if err != nil {
+				return nil, err
+			}
+			return &captureConn{Conn: conn}, nil
+		},
 		// Bağlantı ayarlarını optimize et
 		MaxIdleConns:        100,
 		MaxIdleConnsPerHost: 10,
@@ -0,0 +1,156 @@
+package report
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RunTimeFormat her çalıştırma için açılan alt klasörün isim formatı
+const RunTimeFormat = "2006-01-02_15-04-05"
+
+// LatestName son çalıştırmayı gösteren sembolik link (veya düz dosya) adı
+const LatestName = "latest"
+
+// PrepareRunDirectory hedef dosyası klasörünün altında zaman damgalı yeni bir çalıştırma klasörü açar.
+// Önceki çalıştırmalara dokunulmaz; temizlik ApplyRetention ile yapılır.
+func PrepareRunDirectory(baseDir string) (string, error) {
+	if err := os.MkdirAll(baseDir, 0755); err != nil {
+		return "", fmt.Errorf("klasör oluşturulamadı: %v", err)
+	}
+
+	name := time.Now().Format(RunTimeFormat)
+	runDir := filepath.Join(baseDir, name)
+	// Aynı saniyede ikinci bir çalıştırma varsa sonuna sıra numarası ekle
+	for i := 2; ; i++ {
+		if _, err := os.Stat(runDir); os.IsNotExist(err) {
+			break
+		}
+		runDir = filepath.Join(baseDir, fmt.Sprintf("%s_%d", name, i))
+	}
+
+	if err := os.Mkdir(runDir, 0755); err != nil {
+		return "", fmt.Errorf("çalıştırma klasörü oluşturulamadı: %v", err)
+	}
+	return runDir, nil
+}
+
+// UpdateLatest "latest" işaretçisini verilen çalıştırmaya çevirir.
+// Sembolik link oluşturulamazsa (örn. yetkisiz Windows) çalıştırma adını içeren düz dosya yazar.
+func UpdateLatest(baseDir, runDir string) error {
+	latest := filepath.Join(baseDir, LatestName)
+	if err := os.Remove(latest); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("eski latest işaretçisi silinemedi: %v", err)
+	}
+
+	name := filepath.Base(runDir)
+	if err := os.Symlink(name, latest); err == nil {
+		return nil
+	}
+	return os.WriteFile(latest, []byte(name+"\n"), 0644)
+}
+
+// LatestRun "latest" işaretçisinin gösterdiği çalıştırma klasörünü döndürür
+func LatestRun(baseDir string) (string, error) {
+	latest := filepath.Join(baseDir, LatestName)
+	info, err := os.Lstat(latest)
+	if err != nil {
+		return "", err
+	}
+
+	if info.Mode()&os.ModeSymlink != 0 {
+		target, err := os.Readlink(latest)
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(baseDir, filepath.Base(target)), nil
+	}
+
+	data, err := os.ReadFile(latest)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(baseDir, strings.TrimSpace(string(data))), nil
+}
+
+// ListRuns hedef klasöründeki çalıştırma klasörlerini eskiden yeniye sıralı döndürür
+func ListRuns(baseDir string) ([]string, error) {
+	entries, err := os.ReadDir(baseDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var runs []string
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		if _, ok := runTime(e.Name()); ok {
+			runs = append(runs, filepath.Join(baseDir, e.Name()))
+		}
+	}
+	sort.Strings(runs) // Zaman formatı sözlük sırasıyla kronolojik sıralanır
+	return runs, nil
+}
+
+// ApplyRetention saklama politikasına göre eski çalıştırmaları siler ve silinenleri döndürür.
+// keepLast: son N çalıştırma tutulur, maxAgeDays: N günden eski çalıştırmalar silinir (0 = kapalı).
+// current her durumda korunur.
+func ApplyRetention(baseDir string, keepLast, maxAgeDays int, current string) ([]string, error) {
+	runs, err := ListRuns(baseDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []string
+	for i, run := range runs {
+		if run == current {
+			continue
+		}
+
+		expired := keepLast > 0 && i < len(runs)-keepLast
+		if maxAgeDays > 0 {
+			if t, ok := runTime(filepath.Base(run)); ok && time.Since(t) > time.Duration(maxAgeDays)*24*time.Hour {
+				expired = true
+			}
+		}
+		if !expired {
+			continue
+		}
+
+		if err := os.RemoveAll(run); err != nil {
+			return removed, fmt.Errorf("çalıştırma silinemedi (%s): %v", run, err)
+		}
+		removed = append(removed, run)
+	}
+	return removed, nil
+}
+
+// runTime klasör adından çalıştırma zamanını çıkarır ("_2" gibi sıra ekleri yok sayılır)
+func runTime(name string) (time.Time, bool) {
+	if len(name) < len(RunTimeFormat) {
+		return time.Time{}, false
+	}
+	t, err := time.ParseInLocation(RunTimeFormat, name[:len(RunTimeFormat)], time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// PreviousRun aynı hedef klasöründe verilen çalıştırmadan hemen önceki çalıştırmayı döndürür
+func PreviousRun(runDir string) (string, bool) {
+	runs, err := ListRuns(filepath.Dir(runDir))
+	if err != nil {
+		return "", false
+	}
+	for i, run := range runs {
+		if run == runDir && i > 0 {
+			return runs[i-1], true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,211 @@
+package report
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WARCFile tüm istek/yanıt çiftlerinin yazıldığı arşiv dosyası
+const WARCFile = "scan.warc.gz"
+
+var (
+	warcFile *os.File
+	// warcMu sadece dosyaya yazmayı korur; kayıtlar kilit dışında hazırlanır ki büyük gövdeler logları bekletmesin
+	warcMu sync.Mutex
+)
+
+// InitWARC WARC 1.1 arşivini açar ve taramayı tanımlayan warcinfo kaydını yazar
+func InitWARC(outputDir string, fields map[string]string) error {
+	f, err := os.OpenFile(filepath.Join(outputDir, WARCFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+
+	var body strings.Builder
+	body.WriteString("software: galileoff. OnionScraper\r\n")
+	body.WriteString("format: WARC File Format 1.1\r\n")
+	body.WriteString("conformsTo: http://iipc.github.io/warc-specifications/specifications/warc-format/warc-1.1/\r\n")
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&body, "%s: %s\r\n", k, fields[k])
+	}
+
+	record, err := buildWARCRecord([][2]string{
+		{"WARC-Type", "warcinfo"},
+		{"WARC-Record-ID", newRecordID()},
+		{"WARC-Date", warcDate(time.Now())},
+		{"WARC-Filename", WARCFile},
+		{"Content-Type", "application/warc-fields"},
+	}, []byte(body.String()))
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	warcMu.Lock()
+	defer warcMu.Unlock()
+	warcFile = f
+	_, err = warcFile.Write(record)
+	return err
+}
+
+// WriteWARC bir yanıtı ve onu üreten isteği response + request kayıtları olarak arşive ekler.
+// Kayıtlar resp.Request'ten (yönlendirmelerde o adımın isteği) oluşturulur; body yanıtın
+// okunmuş (ve varsa Go tarafından açılmış) gövdesidir.
+func WriteWARC(resp *http.Response, body []byte) error {
+	if !warcEnabled() || resp.Request == nil {
+		return nil
+	}
+
+	req := resp.Request
+	target := req.URL.String()
+	now := warcDate(time.Now())
+	responseID := newRecordID()
+
+	// Yanıt bloğu: durum satırı + header'lar + gövde
+	var block bytes.Buffer
+	fmt.Fprintf(&block, "HTTP/%d.%d %s\r\n", resp.ProtoMajor, resp.ProtoMinor, resp.Status)
+	header := resp.Header.Clone()
+	// Gövde parçalı/sıkıştırılmış gelse de arşive açılmış hali yazıldığı için uzunluğu düzelt
+	header.Del("Transfer-Encoding")
+	if resp.Uncompressed {
+		header.Del("Content-Encoding")
+	}
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+	header.Write(&block)
+	block.WriteString("\r\n")
+	block.Write(body)
+
+	responseRecord, err := buildWARCRecord([][2]string{
+		{"WARC-Type", "response"},
+		{"WARC-Record-ID", responseID},
+		{"WARC-Date", now},
+		{"WARC-Target-URI", target},
+		{"Content-Type", "application/http;msgtype=response"},
+		{"WARC-Payload-Digest", digest(body)},
+	}, block.Bytes())
+	if err != nil {
+		return err
+	}
+
+	reqBlock, err := httputil.DumpRequestOut(req, false)
+	if err != nil {
+		return err
+	}
+	requestRecord, err := buildWARCRecord([][2]string{
+		{"WARC-Type", "request"},
+		{"WARC-Record-ID", newRecordID()},
+		{"WARC-Date", now},
+		{"WARC-Target-URI", target},
+		{"WARC-Concurrent-To", responseID},
+		{"Content-Type", "application/http;msgtype=request"},
+	}, reqBlock)
+	if err != nil {
+		return err
+	}
+
+	warcMu.Lock()
+	defer warcMu.Unlock()
+	if warcFile == nil {
+		return nil
+	}
+	// İki kayıt arka arkaya yazılsın diye tek seferde
+	_, err = warcFile.Write(append(responseRecord, requestRecord...))
+	return err
+}
+
+// ArchiveRedirect http.Client.CheckRedirect olarak kullanılır: takip edilen her yönlendirme
+// yanıtını (gövdesiyle) arşive yazar ve Go'nun varsayılan 10 yönlendirme sınırını korur
+func ArchiveRedirect(req *http.Request, via []*http.Request) error {
+	if resp := req.Response; resp != nil && warcEnabled() {
+		// İstemci bu gövdeyi zaten atacak; önce biz okuyoruz
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxRedirectBody))
+		if err := WriteWARC(resp, body); err != nil {
+			Log("ERROR", fmt.Sprintf("%s yönlendirmesi WARC'a yazılamadı: %v", resp.Request.URL, err))
+		}
+	}
+	if len(via) >= 10 {
+		return errors.New("10 yönlendirmeden sonra durduruldu")
+	}
+	return nil
+}
+
+// maxRedirectBody yönlendirme yanıtlarından arşivlenen en fazla gövde boyutu
+const maxRedirectBody = 1 << 20
+
+func warcEnabled() bool {
+	warcMu.Lock()
+	defer warcMu.Unlock()
+	return warcFile != nil
+}
+
+// closeWARC arşiv dosyasını kapatır
+func closeWARC() {
+	warcMu.Lock()
+	defer warcMu.Unlock()
+	if warcFile != nil {
+		warcFile.Close()
+		warcFile = nil
+	}
+}
+
+// buildWARCRecord tek bir kaydı ayrı gzip üyesi olarak hazırlar
+func buildWARCRecord(headers [][2]string, block []byte) ([]byte, error) {
+	var record bytes.Buffer
+	record.WriteString("WARC/1.1\r\n")
+	for _, h := range headers {
+		fmt.Fprintf(&record, "%s: %s\r\n", h[0], h[1])
+	}
+	fmt.Fprintf(&record, "WARC-Block-Digest: %s\r\n", digest(block))
+	fmt.Fprintf(&record, "Content-Length: %d\r\n\r\n", len(block))
+	record.Write(block)
+	record.WriteString("\r\n\r\n")
+
+	var out bytes.Buffer
+	gz := gzip.NewWriter(&out)
+	if _, err := gz.Write(record.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// digest WARC'ın beklediği sha1:BASE32 formatında özet üretir
+func digest(data []byte) string {
+	sum := sha1.Sum(data)
+	return "sha1:" + base32.StdEncoding.EncodeToString(sum[:])
+}
+
+func warcDate(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05Z")
+}
+
+// newRecordID rastgele urn:uuid kayıt kimliği üretir
+func newRecordID() string {
+	var u [16]byte
+	rand.Read(u[:])
+	u[6] = (u[6] & 0x0f) | 0x40
+	u[8] = (u[8] & 0x3f) | 0x80
+	return fmt.Sprintf("<urn:uuid:%x-%x-%x-%x-%x>", u[0:4], u[4:6], u[6:8], u[8:10], u[10:16])
+}
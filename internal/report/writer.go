@@ -1,9 +1,14 @@
 package report
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"galileoff-OnionScraper/internal/classifier"
+	"galileoff-OnionScraper/internal/leaksite"
+	"galileoff-OnionScraper/internal/similarity"
 	"galileoff-OnionScraper/internal/utils"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -81,8 +86,8 @@ func LogFooter(total, success, failed, totalLinks int, duration time.Duration, t
 	logFile.WriteString(footer)
 }
 
-// Log verilen mesajı log dosyasına yazar
-func Log(level, message string) {
+// LogClusters benzer site kümelerini log dosyasına verilen başlıkla ayrı bir bölüm olarak yazar
+func LogClusters(title string, clusters []similarity.Cluster) {
 	mu.Lock()
 	defer mu.Unlock()
 
@@ -90,29 +95,39 @@ func Log(level, message string) {
 		return
 	}
 
-	// [ZAMAN] [LEVEL] Mesaj
-	timestamp := time.Now().Format("15:04:05")
-	entry := fmt.Sprintf("[%s] [%s] %s\n", timestamp, level, message)
+	border := strings.Repeat("=", 60)
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n%s\n  %s (%d Küme)\n%s\n", border, title, len(clusters), border)
 
-	logFile.WriteString(entry)
-}
+	if len(clusters) == 0 {
+		b.WriteString("  [!] Benzer site grubu bulunamadı\n")
+	}
 
-// PrepareOutputDirectory belirtilen klasörü hazırlar (varsa içindekileri temizler, yoksa oluşturur)
-func PrepareOutputDirectory(dirName string) error {
-	// Klasör varsa içini temizle
-	if _, err := os.Stat(dirName); err == nil {
-		// Klasörü sil
-		if err := os.RemoveAll(dirName); err != nil {
-			return fmt.Errorf("klasör temizlenemedi: %v", err)
+	for _, c := range clusters {
+		fmt.Fprintf(&b, "  KÜME #%d - %d Hedef - Ortalama Benzerlik: %%%.0f\n", c.ID, len(c.Members), c.AvgScore*100)
+		for _, m := range c.Members {
+			defanged := utils.Defang(m.URL)
+			fmt.Fprintf(&b, "    [+] %%%-4.0f %s\n", m.Score*100, defanged)
 		}
 	}
 
-	// Klasörü yeniden oluştur
-	if err := os.MkdirAll(dirName, 0755); err != nil {
-		return fmt.Errorf("klasör oluşturulamadı: %v", err)
+	logFile.WriteString(b.String())
+}
+
+// Log verilen mesajı log dosyasına yazar
+func Log(level, message string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if logFile == nil {
+		return
 	}
 
-	return nil
+	// [ZAMAN] [LEVEL] Mesaj
+	timestamp := time.Now().Format("15:04:05")
+	entry := fmt.Sprintf("[%s] [%s] %s\n", timestamp, level, message)
+
+	logFile.WriteString(entry)
 }
 
 // SaveHTML kazıdığımız HTML içeriğini belirtilen klasöre kaydeder
@@ -122,22 +137,47 @@ func SaveHTML(url, content, outputDir string) error {
 		return err
 	}
 
-	safeName := sanitizeFilename(url) + ".html"
-	path := filepath.Join(outputDir, safeName)
+	path := filepath.Join(outputDir, FileName(url, ".html"))
 
 	return os.WriteFile(path, []byte(content), 0644)
 }
 
-// SaveScreenshot ekran görüntüsünü belirtilen klasöre kaydeder
-func SaveScreenshot(url string, data []byte, outputDir string) error {
+// SaveRawHTML render modunda sunucunun gönderdiği ham gövdeyi DOM'un yanına kaydeder
+func SaveRawHTML(url, content, outputDir string) error {
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
 		return err
 	}
 
-	safeName := sanitizeFilename(url) + ".png"
-	path := filepath.Join(outputDir, safeName)
+	return os.WriteFile(filepath.Join(outputDir, FileName(url, ".raw.html")), []byte(content), 0644)
+}
+
+// SaveScreenshot ekran görüntüsünü belirtilen klasöre kaydeder ve dosya adını döndürür.
+// Uzantı görüntünün gerçek formatından seçilir (.png veya .jpg).
+func SaveScreenshot(url string, data []byte, outputDir string) (string, error) {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", err
+	}
 
-	return os.WriteFile(path, data, 0644)
+	name := FileName(url, ScreenshotExt(data))
+	return name, os.WriteFile(filepath.Join(outputDir, name), data, 0644)
+}
+
+// SaveSnapshot PDF/MHTML gibi arşiv çıktılarını ekran görüntüsüyle aynı isimlendirmeyle kaydeder
+func SaveSnapshot(url, ext string, data []byte, outputDir string) (string, error) {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", err
+	}
+
+	name := FileName(url, ext)
+	return name, os.WriteFile(filepath.Join(outputDir, name), data, 0644)
+}
+
+// ScreenshotExt görüntü baytlarına göre dosya uzantısını döndürür
+func ScreenshotExt(data []byte) string {
+	if http.DetectContentType(data) == "image/jpeg" {
+		return ".jpg"
+	}
+	return ".png"
 }
 
 // SaveLinks linkleri dosyaya kaydeder
@@ -169,8 +209,7 @@ func SaveLinks(url, sourceTag string, links []utils.LinkData, outputDir string)
 		// İçerik Analizi Yap
 		classification := classifier.AnalyzeLinkContext(link.URL, link.Text)
 
-		// .onion -> [.]onion
-		defanged := strings.Replace(link.URL, ".onion", "[.]onion", -1)
+		defanged := utils.Defang(link.URL)
 
 		// Örn: [+] [LOGIN?] http://...
 		f.WriteString(fmt.Sprintf("  [+] %-15s %s\n", classification.Tag+"?", defanged))
@@ -180,6 +219,43 @@ func SaveLinks(url, sourceTag string, links []utils.LinkData, outputDir string)
 	return nil
 }
 
+// SaveVictims sızıntı sitelerinden çıkarılan kurbanları victims.json ve victims.csv olarak kaydeder
+func SaveVictims(victims []leaksite.Victim, outputDir string) error {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(victims, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(outputDir, "victims.json"), data, 0644); err != nil {
+		return err
+	}
+
+	f, err := os.Create(filepath.Join(outputDir, "victims.csv"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	w.Write([]string{"group", "name", "domain", "country", "post_date", "deadline", "data_size", "status", "source_url"})
+	for _, v := range victims {
+		// Kaynak adresi defang ederek yaz (tablo programında yanlışlıkla tıklanmasın)
+		source := utils.Defang(v.SourceURL)
+		w.Write([]string{v.Group, v.Name, v.Domain, v.Country, v.PostDate, v.Deadline, v.DataSize, v.Status, source})
+	}
+	w.Flush()
+
+	return w.Error()
+}
+
+// FileName URL için çıktı klasöründe kullanılan dosya adını döndürür (örn: site.onion_.png)
+func FileName(url, ext string) string {
+	return sanitizeFilename(url) + ext
+}
+
 // sanitizeFilename URL'den güvenli dosya adı oluşturur
 func sanitizeFilename(url string) string {
 	safeName := strings.Replace(url, "http://", "", -1)
@@ -189,9 +265,15 @@ func sanitizeFilename(url string) string {
 	return safeName
 }
 
-// Close log dosyasını kapatır
+// Close log ve WARC dosyalarını kapatır
 func Close() {
+	closeWARC()
+
+	mu.Lock()
+	defer mu.Unlock()
+
 	if logFile != nil {
 		logFile.Close()
+		logFile = nil
 	}
 }
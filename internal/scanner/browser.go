@@ -0,0 +1,241 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/target"
+	"github.com/chromedp/chromedp"
+
+	"galileoff-OnionScraper/internal/network"
+	"galileoff-OnionScraper/internal/report"
+)
+
+// TabsPerBrowser tek tarayıcı sürecinde aynı anda açık tutulan en fazla sekme sayısı
+const TabsPerBrowser = 4
+
+// RecycleAfter bir tarayıcı bu kadar hedef işledikten sonra bellek şişmesine karşı yeniden başlatılır
+const RecycleAfter = 100
+
+// BrowserPool tarama boyunca açık tutulan headless tarayıcı havuzu.
+// Toplam sekme sayısı worker sayısına eşittir; her hedef kendi izole
+// tarayıcı bağlamında (ayrı çerez/depolama) açılır ve iş bitince kapatılır.
+type BrowserPool struct {
+	proxyAddr string
+	execPath  string
+	slots     chan *browserInstance
+	instances []*browserInstance
+
+	// Açık ağı kapatan sekmeler için sadece .onion'a izin veren yerel SOCKS aşaması (ilk ihtiyaçta başlar)
+	onionOnce  sync.Once
+	onionProxy *network.OnionProxy
+	onionErr   error
+}
+
+// browserInstance tek bir tarayıcı süreci
+type browserInstance struct {
+	id          int
+	mu          sync.Mutex
+	ctx         context.Context // Tarayıcının kök chromedp bağlamı
+	cancel      context.CancelFunc
+	allocCancel context.CancelFunc
+	uses        int // Son başlatmadan beri işlenen hedef sayısı
+	active      int // Şu an açık olan sekme sayısı
+}
+
+// NewBrowserPool worker sayısı kadar sekme sunan havuzu hazırlar.
+// Tarayıcılar ilk ihtiyaç anında başlatılır.
+func NewBrowserPool(proxyAddr, execPath string, size int) *BrowserPool {
+	if size < 1 {
+		size = 1
+	}
+	p := &BrowserPool{
+		proxyAddr: proxyAddr,
+		execPath:  execPath,
+		slots:     make(chan *browserInstance, size),
+	}
+
+	browserCount := (size + TabsPerBrowser - 1) / TabsPerBrowser
+	for i := 0; i < browserCount; i++ {
+		p.instances = append(p.instances, &browserInstance{id: i + 1})
+	}
+	// Sekme yuvalarını tarayıcılara sırayla dağıt
+	for i := 0; i < size; i++ {
+		p.slots <- p.instances[i%browserCount]
+	}
+	return p
+}
+
+// Tab havuzdan bir sekme yuvası alır ve hedef için izole bir tarayıcı bağlamı açar.
+// onionOnly true ise bağlam .onion dışındaki bağlantıları reddeden yerel proxy üzerinden çıkar;
+// proxy başlatılamazsa sekme korumasız açılmaz, hata döner.
+// Dönen release fonksiyonu sekmeyi kapatır ve yuvayı havuza geri verir; her durumda çağrılmalıdır.
+func (p *BrowserPool) Tab(timeout time.Duration, onionOnly bool) (context.Context, func(), error) {
+	var contextOpts []chromedp.CreateBrowserContextOption
+	if onionOnly {
+		addr, err := p.onionProxyAddr()
+		if err != nil {
+			return nil, func() {}, fmt.Errorf("açık ağ filtresi başlatılamadı: %v", err)
+		}
+		contextOpts = append(contextOpts, func(params *target.CreateBrowserContextParams) *target.CreateBrowserContextParams {
+			// "<-loopback>": yerel adresler de proxy'ye uğrar ve reddedilir
+			return params.WithProxyServer("socks5://" + addr).WithProxyBypassList("<-loopback>")
+		})
+	}
+
+	inst := <-p.slots
+
+	browserCtx, err := inst.acquire(p.proxyAddr, p.execPath)
+	if err != nil {
+		p.slots <- inst
+		return nil, func() {}, err
+	}
+
+	tabCtx, tabCancel := chromedp.NewContext(browserCtx, chromedp.WithNewBrowserContext(contextOpts...))
+	ctx, cancel := context.WithTimeout(tabCtx, timeout)
+
+	release := func() {
+		cancel()
+		tabCancel()
+		inst.release()
+		p.slots <- inst
+	}
+	return ctx, release, nil
+}
+
+// Close havuzdaki tüm tarayıcıları ve açık ağ filtresini kapatır
+func (p *BrowserPool) Close() {
+	for _, inst := range p.instances {
+		inst.mu.Lock()
+		inst.stop()
+		inst.mu.Unlock()
+	}
+	if p.onionProxy != nil {
+		p.onionProxy.Close()
+	}
+}
+
+// onionProxyAddr filtreleyici proxy'yi gerekirse başlatır ve adresini döndürür
+func (p *BrowserPool) onionProxyAddr() (string, error) {
+	p.onionOnce.Do(func() {
+		p.onionProxy, p.onionErr = network.StartOnionProxy(p.proxyAddr, func(host string) {
+			report.Log("DEBUG", fmt.Sprintf("Tarayıcı politikası gereği açık ağ bağlantısı reddedildi: %s", host))
+		})
+		if p.onionErr == nil {
+			report.Log("INFO", fmt.Sprintf("Açık ağ filtresi başlatıldı (%s -> Tor %s).", p.onionProxy.Addr(), p.proxyAddr))
+		}
+	})
+	if p.onionErr != nil {
+		return "", p.onionErr
+	}
+	return p.onionProxy.Addr(), nil
+}
+
+// acquire tarayıcının çalıştığından emin olur (gerekirse başlatır / yeniden başlatır)
+func (b *browserInstance) acquire(proxyAddr, execPath string) (context.Context, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.ctx != nil && b.crashed() {
+		report.Log("WARNING", fmt.Sprintf("Tarayıcı #%d çökmüş, yeniden başlatılıyor.", b.id))
+		b.stop()
+	}
+	if b.ctx != nil && b.uses >= RecycleAfter && b.active == 0 {
+		report.Log("INFO", fmt.Sprintf("Tarayıcı #%d %d hedeften sonra yenileniyor.", b.id, b.uses))
+		b.stop()
+	}
+	if b.ctx == nil {
+		if err := b.start(proxyAddr, execPath); err != nil {
+			return nil, err
+		}
+	}
+
+	b.uses++
+	b.active++
+	return b.ctx, nil
+}
+
+// release sekme kapandığında çağrılır; çöken tarayıcı açık sekmesi kalmadıysa hemen kapatılır
+func (b *browserInstance) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.active--
+	if b.ctx != nil && b.active == 0 && b.crashed() {
+		b.stop()
+	}
+}
+
+func (b *browserInstance) start(proxyAddr, execPath string) error {
+	allocCtx, allocCancel := chromedp.NewExecAllocator(context.Background(), browserOptions(proxyAddr, execPath)...)
+	ctx, cancel := chromedp.NewContext(allocCtx)
+
+	// Boş Run tarayıcı sürecini başlatır
+	if err := chromedp.Run(ctx); err != nil {
+		cancel()
+		allocCancel()
+		return fmt.Errorf("tarayıcı başlatılamadı: %v", err)
+	}
+
+	b.ctx, b.cancel, b.allocCancel = ctx, cancel, allocCancel
+	b.uses = 0
+	report.Log("INFO", fmt.Sprintf("Tarayıcı #%d başlatıldı.", b.id))
+	return nil
+}
+
+func (b *browserInstance) stop() {
+	if b.ctx == nil {
+		return
+	}
+	b.cancel()
+	b.allocCancel()
+	b.ctx, b.cancel, b.allocCancel = nil, nil, nil
+}
+
+// crashed tarayıcı süreciyle bağlantının kopup kopmadığını kontrol eder
+func (b *browserInstance) crashed() bool {
+	if b.ctx.Err() != nil {
+		return true
+	}
+	c := chromedp.FromContext(b.ctx)
+	if c == nil || c.Browser == nil {
+		return true
+	}
+	select {
+	case <-c.Browser.LostConnection:
+		return true
+	default:
+		return false
+	}
+}
+
+// browserOptions tüm tarayıcılar için ortak başlatma bayrakları (Tor proxy ve sızıntı önlemleri)
+func browserOptions(proxyAddr, execPath string) []chromedp.ExecAllocatorOption {
+	opts := append(chromedp.DefaultExecAllocatorOptions[:],
+		chromedp.ProxyServer("socks5://"+proxyAddr),
+		chromedp.Flag("headless", true),
+		chromedp.Flag("disable-gpu", true),
+		chromedp.Flag("no-sandbox", true),
+		// WebRTC ile IP sızıntısı önleme
+		chromedp.Flag("disable-webrtc", true),
+		chromedp.Flag("force-webrtc-ip-handling-policy", "disable_non_proxied_udp"),
+		// DNS sızıntı önleme
+		chromedp.Flag("host-resolver-rules", "MAP * ~NOTFOUND , EXCLUDE 127.0.0.1"),
+		// Gereksiz servisleri kapatma
+		chromedp.Flag("disable-sync", true),
+		chromedp.Flag("disable-background-networking", true),
+		chromedp.WindowSize(1280, 1024),
+	)
+
+	// settings.yaml'daki ek bayraklar varsayılanları ezebilir
+	for name, value := range extraFlags() {
+		opts = append(opts, chromedp.Flag(name, value))
+	}
+
+	if execPath != "" {
+		opts = append(opts, chromedp.ExecPath(execPath))
+	}
+	return opts
+}
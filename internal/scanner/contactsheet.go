@@ -0,0 +1,123 @@
+package scanner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"galileoff-OnionScraper/internal/config"
+	"galileoff-OnionScraper/internal/imaging"
+	"galileoff-OnionScraper/internal/report"
+	"galileoff-OnionScraper/internal/utils"
+)
+
+const (
+	// ThumbnailDir küçük resimlerin çıktı klasöründeki alt klasörü
+	ThumbnailDir = "thumbs"
+
+	thumbnailQuality    = 80
+	contactSheetQuality = 85
+)
+
+// processScreenshot kaydedilen ekran görüntüsünü bir kez çözüp görsel hash'ini ve küçük resmini üretir
+func processScreenshot(url string, screenshot []byte, outputDir string) (*VisualHash, string) {
+	hashEnabled := config.GlobalSettings.VisualHash.Enabled
+	sheet := config.GlobalSettings.ContactSheet
+	if !hashEnabled && !sheet.Enabled {
+		return nil, ""
+	}
+
+	img, err := imaging.Decode(screenshot)
+	if err != nil {
+		report.Log("ERROR", fmt.Sprintf("%s için ekran görüntüsü çözülemedi, görsel hash ve küçük resim atlandı: %v", url, err))
+		return nil, ""
+	}
+
+	var visualHash *VisualHash
+	if hashEnabled {
+		visualHash = computeVisualHash(url, img)
+	}
+
+	thumbnailFile := ""
+	if sheet.Enabled {
+		data, err := imaging.EncodeJPEG(imaging.Thumbnail(img, sheet.ThumbWidth, sheet.ThumbHeight), thumbnailQuality)
+		if err == nil {
+			var name string
+			if name, err = report.SaveSnapshot(url, ".jpg", data, filepath.Join(outputDir, ThumbnailDir)); err == nil {
+				thumbnailFile = ThumbnailDir + "/" + name
+			}
+		}
+		if err != nil {
+			report.Log("ERROR", fmt.Sprintf("%s için küçük resim kaydedilemedi: %v", url, err))
+		}
+	}
+	return visualHash, thumbnailFile
+}
+
+// saveContactSheets küçük resimleri etikete ve URL'e göre sıralayıp contact_sheet_NN.jpg mozaiklerine yazar.
+// Oluşturulan sayfa sayısını döndürür.
+func saveContactSheets(results []ScanResult, outputDir string) int {
+	settings := config.GlobalSettings.ContactSheet
+
+	var items []ScanResult
+	for _, r := range results {
+		if r.Thumbnail != "" {
+			items = append(items, r)
+		}
+	}
+	if len(items) == 0 {
+		return 0
+	}
+
+	// Aynı kategorideki siteler yan yana gelsin
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Tag != items[j].Tag {
+			return items[i].Tag < items[j].Tag
+		}
+		return items[i].URL < items[j].URL
+	})
+
+	perSheet := settings.PerSheet
+	if perSheet <= 0 {
+		perSheet = len(items)
+	}
+
+	sheets := 0
+	for start := 0; start < len(items); start += perSheet {
+		end := min(start+perSheet, len(items))
+
+		var tiles []imaging.Tile
+		for _, r := range items[start:end] {
+			img, err := imaging.Load(filepath.Join(outputDir, r.Thumbnail))
+			if err != nil {
+				report.Log("ERROR", fmt.Sprintf("%s küçük resmi okunamadı: %v", r.URL, err))
+				continue
+			}
+			defanged := utils.Defang(r.URL)
+			tiles = append(tiles, imaging.Tile{
+				Image: img,
+				Lines: imaging.LabelLines(defanged, r.Tag, settings.ThumbWidth),
+			})
+		}
+
+		sheet := imaging.ContactSheet(tiles, settings.Columns, settings.ThumbWidth, settings.ThumbHeight)
+		if sheet == nil {
+			continue
+		}
+		data, err := imaging.EncodeJPEG(sheet, contactSheetQuality)
+		if err != nil {
+			report.Log("ERROR", fmt.Sprintf("Kontak sayfası oluşturulamadı: %v", err))
+			continue
+		}
+
+		name := fmt.Sprintf("contact_sheet_%02d.jpg", sheets+1)
+		if err := os.WriteFile(filepath.Join(outputDir, name), data, 0644); err != nil {
+			report.Log("ERROR", fmt.Sprintf("%s kaydedilemedi: %v", name, err))
+			continue
+		}
+		sheets++
+		report.Log("INFO", fmt.Sprintf("%s oluşturuldu (%d hedef).", name, len(tiles)))
+	}
+	return sheets
+}
@@ -0,0 +1,140 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"galileoff-OnionScraper/internal/config"
+)
+
+// BrowserInfo ekran görüntüleri için kullanılacak tarayıcı
+type BrowserInfo struct {
+	Name    string
+	Path    string
+	Version string // Okunamazsa boş
+}
+
+type browserCandidate struct {
+	name  string
+	paths []string // Mutlak yollar veya PATH içinde aranacak komut adları
+}
+
+// browserCandidates işletim sistemine göre öncelik sırasıyla denenecek tarayıcılar
+func browserCandidates() []browserCandidate {
+	switch runtime.GOOS {
+	case "windows":
+		programFiles := []string{os.Getenv("ProgramFiles"), os.Getenv("ProgramFiles(x86)"), os.Getenv("LocalAppData")}
+		win := func(rel string) []string {
+			var paths []string
+			for _, base := range programFiles {
+				if base != "" {
+					paths = append(paths, filepath.Join(base, rel))
+				}
+			}
+			return paths
+		}
+		return []browserCandidate{
+			{"Microsoft Edge", append([]string{
+				`C:\Program Files (x86)\Microsoft\Edge\Application\msedge.exe`,
+				`C:\Program Files\Microsoft\Edge\Application\msedge.exe`,
+			}, win(`Microsoft\Edge\Application\msedge.exe`)...)},
+			{"Google Chrome", win(`Google\Chrome\Application\chrome.exe`)},
+			{"Chromium", win(`Chromium\Application\chrome.exe`)},
+			{"Brave", win(`BraveSoftware\Brave-Browser\Application\brave.exe`)},
+		}
+	case "darwin":
+		mac := func(app, binary string) []string {
+			paths := []string{filepath.Join("/Applications", app, "Contents/MacOS", binary)}
+			if home, err := os.UserHomeDir(); err == nil {
+				paths = append(paths, filepath.Join(home, "Applications", app, "Contents/MacOS", binary))
+			}
+			return paths
+		}
+		return []browserCandidate{
+			{"Chromium", mac("Chromium.app", "Chromium")},
+			{"Google Chrome", mac("Google Chrome.app", "Google Chrome")},
+			{"Microsoft Edge", mac("Microsoft Edge.app", "Microsoft Edge")},
+			{"Brave", mac("Brave Browser.app", "Brave Browser")},
+		}
+	default:
+		return []browserCandidate{
+			{"Chromium", []string{"chromium", "chromium-browser", "/snap/bin/chromium", "/usr/lib/chromium/chromium"}},
+			{"Google Chrome", []string{"google-chrome", "google-chrome-stable", "/opt/google/chrome/chrome"}},
+			{"Microsoft Edge", []string{"microsoft-edge", "microsoft-edge-stable", "/opt/microsoft/msedge/msedge"}},
+			{"Brave", []string{"brave-browser", "brave", "/opt/brave.com/brave/brave"}},
+		}
+	}
+}
+
+// ResolveBrowser ayarlardaki yolu, yoksa sistemde kurulu Chromium tabanlı tarayıcıları arar
+func ResolveBrowser() (BrowserInfo, error) {
+	if custom := config.GlobalSettings.Browser.Path; custom != "" {
+		path, err := lookBrowser(custom)
+		if err != nil {
+			return BrowserInfo{}, fmt.Errorf("settings.yaml içindeki tarayıcı yolu kullanılamıyor (%s): %v", custom, err)
+		}
+		return BrowserInfo{Name: "Özel Tarayıcı", Path: path, Version: browserVersion(path)}, nil
+	}
+
+	for _, c := range browserCandidates() {
+		for _, p := range c.paths {
+			if path, err := lookBrowser(p); err == nil {
+				return BrowserInfo{Name: c.name, Path: path, Version: browserVersion(path)}, nil
+			}
+		}
+	}
+	return BrowserInfo{}, fmt.Errorf("Chromium, Chrome, Edge veya Brave bulunamadı")
+}
+
+// lookBrowser mutlak yolu doğrular veya komut adını PATH içinde arar
+func lookBrowser(p string) (string, error) {
+	if filepath.IsAbs(p) {
+		info, err := os.Stat(p)
+		if err != nil {
+			return "", err
+		}
+		if info.IsDir() {
+			return "", fmt.Errorf("dosya değil, klasör")
+		}
+		return p, nil
+	}
+	return exec.LookPath(p)
+}
+
+// browserVersion "--version" çıktısını okur (Windows'ta tarayıcılar konsola yazmadığı için atlanır)
+func browserVersion(path string) string {
+	if runtime.GOOS == "windows" {
+		return ""
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, path, "--version").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// extraFlags settings.yaml'daki "--isim=değer" / "--isim" biçimindeki bayrakları ayrıştırır
+func extraFlags() map[string]interface{} {
+	flags := map[string]interface{}{}
+	for _, f := range config.GlobalSettings.Browser.Flags {
+		f = strings.TrimLeft(strings.TrimSpace(f), "-")
+		if f == "" {
+			continue
+		}
+		if name, value, ok := strings.Cut(f, "="); ok {
+			flags[name] = value
+		} else {
+			flags[f] = true
+		}
+	}
+	return flags
+}
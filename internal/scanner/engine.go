@@ -9,25 +9,68 @@ import (
 	"time"
 
 	"galileoff-OnionScraper/internal/classifier"
+	"galileoff-OnionScraper/internal/config"
+	"galileoff-OnionScraper/internal/fingerprint"
+	"galileoff-OnionScraper/internal/gate"
+	"galileoff-OnionScraper/internal/intel"
+	"galileoff-OnionScraper/internal/leaksite"
 	"galileoff-OnionScraper/internal/network"
 	"galileoff-OnionScraper/internal/report"
+	"galileoff-OnionScraper/internal/similarity"
 	"galileoff-OnionScraper/internal/ui"
 	"galileoff-OnionScraper/internal/utils"
 )
 
 // ScanResult tarama işleminin sonucunu tutar
 type ScanResult struct {
-	URL        string
-	StatusCode int
-	Status     string
-	UsedUA     string
-	Error      error
-	LinkCount  int
-	Tag        string // Sınıflandırma Etiketi
+	URL          string                   `json:"url"`
+	StatusCode   int                      `json:"status_code,omitempty"`
+	Status       string                   `json:"status"`
+	UsedUA       string                   `json:"user_agent,omitempty"`
+	Error        error                    `json:"-"`
+	ErrorText    string                   `json:"error,omitempty"` // JSON çıktısı için Error'un metni
+	ScannedAt    time.Time                `json:"scanned_at"`
+	Timings      Timings                  `json:"timings"`
+	LinkCount    int                      `json:"link_count"`
+	Links        []utils.LinkData         `json:"links,omitempty"`
+	Tag          string                   `json:"tag,omitempty"` // Sınıflandırma Etiketi
+	CategoryID   string                   `json:"category_id,omitempty"`
+	Score        int                      `json:"score"`
+	HTMLFile     string                   `json:"html_file,omitempty"`       // Çıktı klasöründeki HTML dosyası (render modunda DOM)
+	RawHTMLFile  string                   `json:"raw_html_file,omitempty"`   // Render modunda sunucunun gönderdiği ham gövde
+	Rendered     bool                     `json:"rendered,omitempty"`        // Analiz tarayıcıda render edilmiş DOM ile yapıldıysa
+	Screenshot   string                   `json:"screenshot_file,omitempty"` // Çıktı klasöründeki ekran görüntüsü
+	Thumbnail    string                   `json:"thumbnail_file,omitempty"`  // thumbs/ altındaki küçük resim
+	PDFFile      string                   `json:"pdf_file,omitempty"`        // Yazdırma çıktısı (ayarlarda açıksa)
+	MHTMLFile    string                   `json:"mhtml_file,omitempty"`      // Tek dosyalık sayfa arşivi (ayarlarda açıksa)
+	HARFile      string                   `json:"har_file,omitempty"`        // Render sırasındaki ağ kaydı (HAR 1.2)
+	Hosts        []HostStat               `json:"contacted_hosts,omitempty"` // Render sırasında bağlantı kurulan sunucular
+	Gate         *gate.Detection          `json:"gate,omitempty"`            // Asıl site yerine kalınan DDoS/kuyruk/captcha geçidi
+	Victims      []leaksite.Victim        `json:"victims,omitempty"`         // Sızıntı sitesi şablonundan çıkarılan kurbanlar
+	Fingerprints fingerprint.Fingerprints `json:"fingerprints"`              // Favicon, başlık, header ve DOM parmak izleri
+	Similarity   similarity.Signature     `json:"similarity"`                // Ayna/klon tespiti için metin ve yapı SimHash'leri
+	VisualHash   *VisualHash              `json:"visual_hash,omitempty"`     // Ekran görüntüsünün algısal hash'leri
+	Page         utils.PageInfo           `json:"page"`                      // Başlık, meta, çerez, güvenlik başlıkları ve formlar
+	Opsec        []intel.Finding          `json:"opsec,omitempty"`           // Operatörün açık ağ altyapısını sızdıran bulgular
+	Entities     []intel.Entity           `json:"entities,omitempty"`        // İletişim bilgileri ve cüzdan adresleri
 }
 
-// StartScan bir çalışan havuzu (worker pool) ile tarama işlemini başlatır ve (başarılı, başarısız, toplam_link) sayılarını döndürür
-func StartScan(targets []string, concurrency int, outputDir string) (int, int, int) {
+// VisualHash ekran görüntüsünün dHash ve pHash değerleri (16 haneli hex)
+type VisualHash struct {
+	DHash string `json:"dhash"`
+	PHash string `json:"phash"`
+}
+
+// Timings hedef başına geçen süreler (milisaniye)
+type Timings struct {
+	FetchMS      int64 `json:"fetch_ms"`
+	GateMS       int64 `json:"gate_ms,omitempty"` // Geçitte bekleme, tekrar deneme ve analistin çözme süresi
+	ScreenshotMS int64 `json:"screenshot_ms"`
+	TotalMS      int64 `json:"total_ms"`
+}
+
+// StartScan bir çalışan havuzu (worker pool) ile tarama işlemini başlatır ve (başarılı, başarısız, toplam_link, sonuçlar) döndürür
+func StartScan(targets []string, concurrency int, outputDir string) (int, int, int, []ScanResult) {
 	// Sınıflandırma Kurallarını Yükle
 	if err := classifier.LoadRules("config/rules.yaml"); err != nil {
 		ui.PrintWarningBox([]string{
@@ -40,6 +83,14 @@ func StartScan(targets []string, concurrency int, outputDir string) (int, int, i
 		ui.PrintSuccess("Sınıflandırma Kuralları Yüklendi (rules.yaml)")
 	}
 
+	// Sızıntı Sitesi Şablonlarını Yükle (opsiyonel, yoksa sadece etiketleme yapılır)
+	if err := leaksite.LoadTemplates("config/leak_templates.yaml"); err != nil {
+		ui.PrintInfo("Sızıntı sitesi şablonları yüklenemedi, kurban çıkarma devre dışı.")
+		report.Log("WARNING", fmt.Sprintf("leak_templates.yaml yüklenemedi: %v", err))
+	} else {
+		ui.PrintSuccess(fmt.Sprintf("Sızıntı Sitesi Şablonları Yüklendi (%d şablon)", len(leaksite.GlobalTemplates.Templates)))
+	}
+
 	client, proxyAddr, err := network.NewTorClient()
 
 	// Tor bağlantı durumu kontrolü
@@ -57,10 +108,27 @@ func StartScan(targets []string, concurrency int, outputDir string) (int, int, i
 		connectionErr = err
 	} else {
 		ui.PrintSuccess(fmt.Sprintf("Tor bağlantısı başarılı! Kullanılan Port: %s", proxyAddr))
+		// Takip edilen yönlendirmeler de kanıt arşivine her adımıyla yazılsın
+		client.CheckRedirect = report.ArchiveRedirect
 		report.Log("INFO", fmt.Sprintf("Tor bağlantısı kuruldu. Port: %s", proxyAddr))
 		ui.PrintInfo("Gizlilik Modu: Tor Browser İmzası (User-Agent) Aktif")
 	}
 
+	// Ekran görüntüleri için tarama boyunca açık kalan tarayıcı havuzu
+	var pool *BrowserPool
+	browserPath := ""
+	if connectionErr == nil {
+		if browser, err := ResolveBrowser(); err != nil {
+			ui.PrintInfo("Tarayıcı bulunamadı, ekran görüntüleri alınmayacak.")
+			report.Log("WARNING", fmt.Sprintf("Ekran görüntüsü tarayıcısı bulunamadı: %v", err))
+		} else {
+			report.Log("INFO", fmt.Sprintf("Ekran görüntüsü tarayıcısı: %s (%s)", browser.Name, browser.Path))
+			pool = NewBrowserPool(proxyAddr, browser.Path, concurrency)
+			defer pool.Close()
+			browserPath = browser.Path
+		}
+	}
+
 	tasks := make(chan string, len(targets))
 	results := make(chan ScanResult, len(targets))
 	var wg sync.WaitGroup
@@ -71,10 +139,18 @@ func StartScan(targets []string, concurrency int, outputDir string) (int, int, i
 	progress := ui.NewLiveProgress("Hedefler Taranıyor...", len(targets))
 	progress.Start()
 
+	// Captcha geçitleri için analistin çözeceği görünür tarayıcı (sadece etkileşimli modda)
+	var solver *GateSolver
+	if config.GlobalSettings.Gate.Interactive && browserPath != "" {
+		solver = NewGateSolver(proxyAddr, browserPath, func(msg string) {
+			progress.PrintLog(func() { ui.PrintInfo(msg) })
+		})
+	}
+
 	// İşçileri (workers/köle) başlat
 	for i := 0; i < concurrency; i++ {
 		wg.Add(1)
-		go worker(client, proxyAddr, tasks, results, &wg, connectionErr, outputDir)
+		go worker(client, pool, solver, tasks, results, &wg, connectionErr, outputDir)
 	}
 
 	// Görevleri gönder
@@ -93,11 +169,22 @@ func StartScan(targets []string, concurrency int, outputDir string) (int, int, i
 	successCount := 0
 	failCount := 0
 	totalLinks := 0
+	var victims []leaksite.Victim
+	var simItems []similarity.Item
+	var visualItems []similarity.VisualItem
+	gateCount := 0
+	var allResults []ScanResult
 
 	// Sonuçları işle
 	for result := range results {
 		progress.Increment()
 
+		result.ScannedAt = time.Now()
+		if result.Error != nil {
+			result.ErrorText = result.Error.Error()
+		}
+		allResults = append(allResults, result)
+
 		// Spinner'ı bozmadan log yazmak için PrintLog kullanıyoruz
 		progress.PrintLog(func() {
 			if result.Error != nil {
@@ -116,6 +203,21 @@ func StartScan(targets []string, concurrency int, outputDir string) (int, int, i
 			} else {
 				successCount++
 				totalLinks += result.LinkCount
+				victims = append(victims, result.Victims...)
+				// Geçit sayfaları (aynı DDoS koruması/kuyruk şablonu) birbirine benzer;
+				// ilgisiz siteler ayna sayılmasın diye kümelemeye girmez
+				if result.Gate != nil {
+					gateCount++
+				} else {
+					simItems = append(simItems, similarity.Item{
+						URL:         result.URL,
+						Signature:   result.Similarity,
+						FaviconHash: result.Fingerprints.FaviconHash,
+					})
+					if item, ok := visualItem(result); ok {
+						visualItems = append(visualItems, item)
+					}
+				}
 
 				// Başarılı durum: HTTP Kodu ile logla
 				statusText := http.StatusText(result.StatusCode)
@@ -137,11 +239,52 @@ func StartScan(targets []string, concurrency int, outputDir string) (int, int, i
 		})
 	}
 
+	// Kurban kayıtlarını tarama sonuçlarının yanına yaz
+	if len(victims) > 0 {
+		if err := report.SaveVictims(victims, outputDir); err != nil {
+			report.Log("ERROR", fmt.Sprintf("Kurban listesi kaydedilemedi: %v", err))
+		} else {
+			report.Log("INFO", fmt.Sprintf("%d kurban kaydı victims.json / victims.csv dosyalarına yazıldı.", len(victims)))
+		}
+	}
+
+	// Tüm hedeflerin yapılandırılmış sonuçlarını kaydet
+	if err := saveResults(allResults, outputDir); err != nil {
+		report.Log("ERROR", fmt.Sprintf("scan_result.json kaydedilemedi: %v", err))
+	}
+
+	// Ayna ve klon siteleri grupla
+	clusters := similarity.Clusters(simItems, similarity.DefaultThreshold)
+	report.LogClusters("AYNA / KLON KÜMELERİ", clusters)
+
+	// Aynı şablonu kullanan siteleri ekran görüntülerinden grupla
+	var visualClusters []similarity.Cluster
+	if config.GlobalSettings.VisualHash.Enabled {
+		visualClusters = similarity.VisualClusters(visualItems, config.GlobalSettings.VisualHash.MaxDistance)
+		report.LogClusters("GÖRSEL KÜMELER", visualClusters)
+	}
+
 	ui.PrintSectionHeader("Tarama Tamamlandı")
-	return successCount, failCount, totalLinks
+	if len(clusters) > 0 {
+		ui.PrintInfo(fmt.Sprintf("%d ayna/klon kümesi tespit edildi (Detaylar: scan_result.log)", len(clusters)))
+	}
+	if gateCount > 0 {
+		ui.PrintInfo(fmt.Sprintf("%d hedef DDoS/captcha geçidinde kaldı, %s olarak etiketlendi (Detaylar: scan_result.log)", gateCount, gate.Tag))
+	}
+	if len(visualClusters) > 0 {
+		ui.PrintInfo(fmt.Sprintf("%d görsel küme tespit edildi (Detaylar: scan_result.log)", len(visualClusters)))
+	}
+
+	// Tüm ekran görüntülerini tek bakışta gösteren mozaikler
+	if config.GlobalSettings.ContactSheet.Enabled {
+		if sheets := saveContactSheets(allResults, outputDir); sheets > 0 {
+			ui.PrintInfo(fmt.Sprintf("%d kontak sayfası oluşturuldu (contact_sheet_*.jpg)", sheets))
+		}
+	}
+	return successCount, failCount, totalLinks, allResults
 }
 
-func worker(client *http.Client, proxyAddr string, tasks <-chan string, results chan<- ScanResult, wg *sync.WaitGroup, connectionErr error, outputDir string) {
+func worker(client *http.Client, pool *BrowserPool, solver *GateSolver, tasks <-chan string, results chan<- ScanResult, wg *sync.WaitGroup, connectionErr error, outputDir string) {
 	defer wg.Done()
 	for url := range tasks {
 		// Eğer Tor bağlantısı baştan yoksa direkt hata dön
@@ -166,75 +309,140 @@ func worker(client *http.Client, proxyAddr string, tasks <-chan string, results
 		report.Log("INFO", fmt.Sprintf("Tarama Başlatılıyor: %s (Köle Çalışmaya Başladı)", url))
 		statStartTime := time.Now()
 
-		// Request oluştur (User-Agent eklemek için)
-		req, err := http.NewRequest("GET", targetURL, nil)
-
 		// Rastgele User-Agent ve ilgili header'ları ayarla
 		profile := utils.GetRandomProfile()
-		if err != nil {
-			// Request oluşturma hatası
-			report.Log("ERROR", fmt.Sprintf("Request oluşturulamadı [%s]: %v", url, err))
-			results <- ScanResult{URL: url, Status: "FAILED", UsedUA: profile.Name, Error: err}
-			continue
-		}
-
-		// Header'ları ayarla
-		req.Header.Set("User-Agent", profile.UserAgent)
-		for k, v := range profile.Headers {
-			req.Header.Set(k, v)
-		}
-
-		// İsteği gönder
-		resp, err := client.Do(req)
-
-		scanDuration := time.Since(statStartTime)
+		session := &Session{UserAgent: profile.UserAgent}
 
+		page, err := fetchPage(client, url, targetURL, profile, session)
 		if err != nil {
-			// Bağlantı veya zaman aşımı hatası detaylı logla
-			report.Log("FAILED", fmt.Sprintf("Erişim sağlanamadı [%s] (Süre: %s): %v", url, scanDuration, err))
 			results <- ScanResult{URL: url, Status: "FAILED", UsedUA: profile.Name, Error: err}
 			continue
 		}
 
+		// DDoS koruması / bekleme sırası / captcha geçidi varsa aşmaya çalış
+		gateStart := time.Now()
+		gateInfo := passGate(client, solver, url, targetURL, profile, session, &page)
+		gateDuration := time.Since(gateStart)
+
+		resp, body, headerOrder := page.resp, page.body, page.headerOrder
 		statusCode := resp.StatusCode
 		respSize := resp.ContentLength
-
-		// Response Header'larını önemli olanları logla
 		contentType := resp.Header.Get("Content-Type")
 		server := resp.Header.Get("Server")
 
-		report.Log("DEBUG", fmt.Sprintf("Response Alındı [%s] - Status: %d, Size: %d, Type: %s, Server: %s, Süre: %s",
-			url, statusCode, respSize, contentType, server, scanDuration))
-
-		body, err := io.ReadAll(resp.Body)
-		resp.Body.Close()
-
-		if err != nil {
-			report.Log("ERROR", fmt.Sprintf("Response Body okunamadı [%s]: %v", url, err))
-			results <- ScanResult{URL: url, Status: "FAILED", UsedUA: profile.Name, Error: err}
-			continue
+		// Sayfayı tarayıcıda aç: ekran görüntüsü ve (render modunda) JavaScript sonrası DOM
+		// Tarayıcı işlemi biraz zaman alacağı için köleler burada meşgul olacak
+		// Ancak concurrency olduğu için diğer URL'ler işlenmeye devam ediyor
+		renderDOM := config.GlobalSettings.Browser.RenderDOM
+		category := ""
+		if pool != nil && config.GlobalSettings.Screenshot.HasCategoryOverrides() {
+			// Kategoriye özel tarayıcı politikası için ham gövdeden ön sınıflandırma
+			category = preClassify(url, string(body), statusCode, resp.Header)
+		}
+		ssStartTime := time.Now()
+		var capture PageCapture
+		if pool == nil {
+			report.Log("DEBUG", fmt.Sprintf("%s için tarayıcı adımı atlandı (tarayıcı yok).", url))
+		} else if capture, err = CapturePage(pool, url, category, renderDOM, session); err != nil {
+			report.Log("FAILED", fmt.Sprintf("%s tarayıcıda açılamadı: %v", url, err))
+		}
+		ssDuration := time.Since(ssStartTime)
+
+		// Analizin kaynağı: render modunda tarayıcının DOM'u, değilse ham HTTP gövdesi
+		content := string(body)
+		rendered := renderDOM && capture.DOM != ""
+		if rendered {
+			content = capture.DOM
+			report.Log("DEBUG", fmt.Sprintf("%s render edilmiş DOM ile analiz ediliyor (Ham: %d bayt, DOM: %d bayt)", url, len(body), len(content)))
 		}
 
 		// İÇERİK ANALİZİ VE SINIFLANDIRMA
 		// Önce linkleri çıkar (analiz için link sayısı lazım)
-		links := utils.ExtractLinks(string(body))
+		links := utils.ExtractLinks(content)
 		linkCount := len(links)
 
 		// Sınıflandırma motorunu çalıştır
-		analysisResult := classifier.Analyze(string(body), url, linkCount)
+		analysisResult := classifier.Analyze(content, url, linkCount)
+
+		// Hâlâ geçitteyse asıl site değil geçit sınıflandırılmış olur.
+		// Render modunda JS kontrolü tarayıcıda geçilmiş (veya tarayıcı geçide düşmüş) olabileceği için DOM'a tekrar bakılır.
+		if rendered {
+			gateInfo = gate.Detect(content, classifier.VisibleText(content), statusCode, resp.Header)
+		}
+		if gateInfo != nil {
+			analysisResult = classifier.Result{CategoryID: gate.CategoryID, Tag: gate.Tag, Score: 100}
+		}
 
 		// Analiz sonucunu logla
 		report.Log("ANALİZ", fmt.Sprintf("%s URL: %s - Skor: %d", analysisResult.Tag, url, analysisResult.Score))
 		report.Log("DEBUG", fmt.Sprintf("Response [%s] - Status: %d, Size: %d, Type: %s, Server: %s, Etiket: %s",
 			url, statusCode, respSize, contentType, server, analysisResult.Tag))
 
+		// Parmak izlerini hesapla (favicon ayrıca çekilir)
+		var headerNames []string
+		for name := range resp.Header {
+			headerNames = append(headerNames, name)
+		}
+		// Parmak izleri sunucunun gönderdiği ham gövdeden hesaplanır (JS'den bağımsız, kit/operatör eşleştirmesi için)
+		fingerprints := fingerprint.Compute(string(body), headerOrder(), headerNames, server)
+		fingerprints.FaviconURL = fingerprint.FaviconURL(content, targetURL)
+		if icon, err := fetchFavicon(client, fingerprints.FaviconURL, profile); err != nil {
+			report.Log("DEBUG", fmt.Sprintf("Favicon alınamadı [%s]: %v", url, err))
+		} else {
+			fingerprints.FaviconHash = fingerprint.FaviconHash(icon)
+		}
+		report.Log("DEBUG", fmt.Sprintf("Parmak İzi [%s] - Favicon: %d, Başlık: %s, Header: %s, DOM: %s",
+			url, fingerprints.FaviconHash, fingerprints.TitleHash, fingerprints.HeaderHash, fingerprints.DOMHash))
+
+		// Sayfa envanteri (meta, çerez isimleri, güvenlik başlıkları, formlar)
+		pageInfo := utils.ExtractPageInfo(content, resp.Header)
+		for _, form := range pageInfo.Forms {
+			if form.HasPassword || form.HasUpload {
+				report.Log("INFO", fmt.Sprintf("%s adresinde dikkat çeken form: %s %s (Şifre: %t, Dosya Yükleme: %t, Alan: %d)",
+					url, form.Method, form.Action, form.HasPassword, form.HasUpload, len(form.Inputs)))
+			}
+		}
+
+		// Operatör gizlilik hataları (açık ağ IP/alan adı, analitik ID, hata çıktıları...)
+		opsecFindings := intel.DetectOpsecLeaks(content)
+		for _, f := range opsecFindings {
+			report.Log("OPSEC", fmt.Sprintf("%s [%s] %s -> %s", url, f.Type, f.Value, f.Evidence))
+		}
+
+		// İletişim bilgileri ve kripto cüzdanları
+		entities := intel.ExtractEntities(content)
+		if len(entities) > 0 {
+			report.Log("INFO", fmt.Sprintf("%s adresinden %d iletişim/cüzdan bilgisi çıkarıldı.", url, len(entities)))
+		}
+
+		// Ayna/klon tespiti için benzerlik imzası
+		signature := similarity.Compute(classifier.VisibleText(content), content)
+
+		// Sızıntı sitesi şablonu varsa kurbanları çıkar
+		victims := leaksite.Extract(content, url)
+		if len(victims) > 0 {
+			report.Log("INFO", fmt.Sprintf("%s adresinden %d kurban kaydı çıkarıldı (Grup: %s)", url, len(victims), victims[0].Group))
+		}
+
 		// HTML içeriğini kaydet
-		if err := report.SaveHTML(url, string(body), outputDir); err != nil {
+		htmlFile := ""
+		if err := report.SaveHTML(url, content, outputDir); err != nil {
 			report.Log("ERROR", fmt.Sprintf("%s için HTML kaydetme hatası: %v", url, err))
 		} else {
+			htmlFile = report.FileName(url, ".html")
 			report.Log("INFO", fmt.Sprintf("HTML Kaydedildi: %s", url))
 		}
 
+		// Render modunda sunucunun gönderdiği ham gövde ayrıca saklanır
+		rawHTMLFile := ""
+		if rendered {
+			if err := report.SaveRawHTML(url, string(body), outputDir); err != nil {
+				report.Log("ERROR", fmt.Sprintf("%s için ham HTML kaydetme hatası: %v", url, err))
+			} else {
+				rawHTMLFile = report.FileName(url, ".raw.html")
+			}
+		}
+
 		// Linkleri ve tahminleri kaydet
 		if err := report.SaveLinks(url, analysisResult.Tag, links, outputDir); err != nil {
 			report.Log("ERROR", fmt.Sprintf("%s için linkler kaydedilemedi: %v", url, err))
@@ -245,36 +453,135 @@ func worker(client *http.Client, proxyAddr string, tasks <-chan string, results
 			// Bulunan linkleri log dosyasına da ekle
 			for _, l := range links {
 				// Güvenlik: Log dosyasında da defang yapalım
-				safeLink := strings.Replace(l.URL, ".onion", "[.]onion", -1)
+				safeLink := utils.Defang(l.URL)
 				report.Log("LINK", fmt.Sprintf("  -> %s", safeLink))
 			}
 		} else {
 			report.Log("INFO", fmt.Sprintf("%s adresinde hiç link bulunamadı.", url))
 		}
 
-		// Ekran görüntüsü al (Hata olursa sadece logla, işlemi başarısız sayma)
-		// Screenshot işlemi biraz zaman alacağı için köleler burada meşgul olacak
-		// Ancak concurrency olduğu için diğer URL'ler işlenmeye devam ediyor
-		ssStartTime := time.Now()
-		if screenshotData, err := CaptureScreenshot(url, proxyAddr); err != nil {
-			report.Log("FAILED", fmt.Sprintf("%s için screenshot alınamadı: %v", url, err))
-		} else {
-			if err := report.SaveScreenshot(url, screenshotData, outputDir); err != nil {
+		// Ekran görüntüsünü kaydet (Hata olursa sadece logla, işlemi başarısız sayma)
+		screenshotFile := ""
+		if len(capture.Screenshot) > 0 {
+			if name, err := report.SaveScreenshot(url, capture.Screenshot, outputDir); err != nil {
 				report.Log("ERROR", fmt.Sprintf("%s için screenshot dosyası kaydedilemedi: %v", url, err))
 			} else {
-				ssDuration := time.Since(ssStartTime)
+				screenshotFile = name
 				report.Log("SUCCESS", fmt.Sprintf("%s için screenshot başarıyla kaydedildi. (Süre: %s)", url, ssDuration))
 			}
 		}
 
+		// Arşiv formatları (hukuki teslim için PDF ve tek dosyalık MHTML)
+		pdfFile := saveSnapshot(url, ".pdf", capture.PDF, outputDir)
+		mhtmlFile := saveSnapshot(url, ".mhtml", capture.MHTML, outputDir)
+		harFile := saveSnapshot(url, ".har", capture.HAR, outputDir)
+
+		// Görsel hash ve küçük resim için görüntü bir kez çözülür
+		var visualHash *VisualHash
+		thumbnailFile := ""
+		if screenshotFile != "" {
+			visualHash, thumbnailFile = processScreenshot(url, capture.Screenshot, outputDir)
+		}
+
+		// Sayfanın açık ağa (clearnet) yaptığı çağrılar ziyaretçiyi ifşa eder
+		for _, h := range capture.Hosts {
+			if h.Onion {
+				continue
+			}
+			if h.Failed == h.Requests {
+				report.Log("OPSEC", fmt.Sprintf("%s render sırasında açık ağa bağlanmaya çalıştı, istekler başarısız/engellendi: %s (%d istek)", url, h.Host, h.Requests))
+			} else {
+				report.Log("OPSEC", fmt.Sprintf("%s render sırasında açık ağa bağlandı: %s (%d istek)", url, h.Host, h.Requests))
+			}
+		}
+
 		results <- ScanResult{
 			URL:        url,
 			StatusCode: statusCode,
 			Status:     "SUCCESS",
 			UsedUA:     profile.Name,
 			Error:      nil,
-			LinkCount:  linkCount,
-			Tag:        analysisResult.Tag,
+			Timings: Timings{
+				FetchMS:      page.duration.Milliseconds(),
+				GateMS:       gateDuration.Milliseconds(),
+				ScreenshotMS: ssDuration.Milliseconds(),
+				TotalMS:      time.Since(statStartTime).Milliseconds(),
+			},
+			LinkCount:    linkCount,
+			Links:        links,
+			Tag:          analysisResult.Tag,
+			CategoryID:   analysisResult.CategoryID,
+			Score:        analysisResult.Score,
+			HTMLFile:     htmlFile,
+			RawHTMLFile:  rawHTMLFile,
+			Rendered:     rendered,
+			Screenshot:   screenshotFile,
+			Thumbnail:    thumbnailFile,
+			PDFFile:      pdfFile,
+			MHTMLFile:    mhtmlFile,
+			HARFile:      harFile,
+			Hosts:        capture.Hosts,
+			Gate:         gateInfo,
+			Victims:      victims,
+			Fingerprints: fingerprints,
+			Similarity:   signature,
+			VisualHash:   visualHash,
+			Page:         pageInfo,
+			Opsec:        opsecFindings,
+			Entities:     entities,
 		}
 	}
 }
+
+// saveSnapshot boş değilse arşiv çıktısını kaydeder ve dosya adını döndürür
+func saveSnapshot(url, ext string, data []byte, outputDir string) string {
+	if len(data) == 0 {
+		return ""
+	}
+	name, err := report.SaveSnapshot(url, ext, data, outputDir)
+	if err != nil {
+		report.Log("ERROR", fmt.Sprintf("%s için %s dosyası kaydedilemedi: %v", url, ext, err))
+		return ""
+	}
+	report.Log("INFO", fmt.Sprintf("%s için %s arşivi kaydedildi.", url, ext))
+	return name
+}
+
+// fetchFavicon favicon dosyasını sayfayla aynı profil ile indirir
+func fetchFavicon(client *http.Client, iconURL string, profile utils.UserAgentProfile) ([]byte, error) {
+	if iconURL == "" {
+		return nil, fmt.Errorf("favicon adresi yok")
+	}
+
+	req, err := http.NewRequest("GET", iconURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", profile.UserAgent)
+	for k, v := range profile.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	// Favicon diye devasa dosya indirmeyelim
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if err := report.WriteWARC(resp, data); err != nil {
+		report.Log("ERROR", fmt.Sprintf("%s için WARC kaydı yazılamadı: %v", iconURL, err))
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("boş favicon")
+	}
+	return data, nil
+}
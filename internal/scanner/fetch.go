@@ -0,0 +1,101 @@
+package scanner
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"galileoff-OnionScraper/internal/network"
+	"galileoff-OnionScraper/internal/report"
+	"galileoff-OnionScraper/internal/utils"
+)
+
+// Session hedef için geçit aşıldıktan sonra korunan oturum bilgisi.
+// HTTP istekleri ve tarayıcı aynı çerezleri ve User-Agent'ı kullanır.
+type Session struct {
+	UserAgent string
+	Cookies   []*http.Cookie
+}
+
+// AddCookies aynı isimli çerezleri günceller, yenileri ekler
+func (s *Session) AddCookies(cookies []*http.Cookie) {
+	for _, c := range cookies {
+		replaced := false
+		for i, old := range s.Cookies {
+			if old.Name == c.Name {
+				s.Cookies[i] = c
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			s.Cookies = append(s.Cookies, c)
+		}
+	}
+}
+
+// fetchedPage tek bir HTTP isteğinin sonucu
+type fetchedPage struct {
+	req         *http.Request
+	resp        *http.Response
+	body        []byte
+	headerOrder func() []string
+	duration    time.Duration
+}
+
+// fetchPage sayfayı profilin header'ları ve oturum çerezleriyle çeker, istek/yanıtı WARC arşivine yazar
+func fetchPage(client *http.Client, url, targetURL string, profile utils.UserAgentProfile, session *Session) (*fetchedPage, error) {
+	start := time.Now()
+
+	// Request oluştur (User-Agent eklemek için)
+	req, err := http.NewRequest("GET", targetURL, nil)
+	if err != nil {
+		// Request oluşturma hatası
+		report.Log("ERROR", fmt.Sprintf("Request oluşturulamadı [%s]: %v", url, err))
+		return nil, err
+	}
+
+	// Header'ları ayarla
+	req.Header.Set("User-Agent", profile.UserAgent)
+	for k, v := range profile.Headers {
+		req.Header.Set(k, v)
+	}
+	if session != nil {
+		for _, c := range session.Cookies {
+			req.AddCookie(&http.Cookie{Name: c.Name, Value: c.Value})
+		}
+	}
+
+	// Header sırasını yakalamak için bağlantıyı takip et
+	req, headerOrder := network.TraceHeaderOrder(req)
+
+	// İsteği gönder
+	resp, err := client.Do(req)
+	duration := time.Since(start)
+
+	if err != nil {
+		// Bağlantı veya zaman aşımı hatası detaylı logla
+		report.Log("FAILED", fmt.Sprintf("Erişim sağlanamadı [%s] (Süre: %s): %v", url, duration, err))
+		return nil, err
+	}
+
+	// Response Header'larını önemli olanları logla
+	report.Log("DEBUG", fmt.Sprintf("Response Alındı [%s] - Status: %d, Size: %d, Type: %s, Server: %s, Süre: %s",
+		url, resp.StatusCode, resp.ContentLength, resp.Header.Get("Content-Type"), resp.Header.Get("Server"), duration))
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if err != nil {
+		report.Log("ERROR", fmt.Sprintf("Response Body okunamadı [%s]: %v", url, err))
+		return nil, err
+	}
+
+	// İstek/yanıt çiftini kanıt arşivine ekle
+	if err := report.WriteWARC(resp, body); err != nil {
+		report.Log("ERROR", fmt.Sprintf("%s için WARC kaydı yazılamadı: %v", url, err))
+	}
+
+	return &fetchedPage{req: req, resp: resp, body: body, headerOrder: headerOrder, duration: duration}, nil
+}
@@ -0,0 +1,77 @@
+package scanner
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"galileoff-OnionScraper/internal/classifier"
+	"galileoff-OnionScraper/internal/config"
+	"galileoff-OnionScraper/internal/gate"
+	"galileoff-OnionScraper/internal/report"
+	"galileoff-OnionScraper/internal/utils"
+)
+
+// detectGate çekilen sayfanın bir geçit (kuyruk, tarayıcı kontrolü, captcha) olup olmadığına bakar
+func detectGate(page *fetchedPage) *gate.Detection {
+	content := string(page.body)
+	return gate.Detect(content, classifier.VisibleText(content), page.resp.StatusCode, page.resp.Header)
+}
+
+// passGate geçide takılan hedefi aşmaya çalışır: bekleme sırasında ayarlardaki kadar bekleyip
+// tekrar dener, hâlâ geçitteyse ve etkileşimli mod açıksa analiste görünür tarayıcıda çözdürür.
+// page geçit sonrası son yanıtla güncellenir; geçit aşılamadıysa son tespit döner.
+func passGate(client *http.Client, solver *GateSolver, url, targetURL string, profile utils.UserAgentProfile, session *Session, page **fetchedPage) *gate.Detection {
+	det := detectGate(*page)
+	if det == nil {
+		return nil
+	}
+	report.Log("GEÇİT", fmt.Sprintf("%s geçit sayfası döndürdü: %s / %s (%q)", url, det.Name, det.Kind, det.Evidence))
+
+	// Kuyruk sıra numarasını, geçit ise geçiş iznini çerezde tutar; her yanıtın çerezi oturuma eklenir
+	// (son yanıtınki dahil, yoksa tarayıcı ve sonraki istekler tekrar geçide düşer)
+	session.AddCookies((*page).resp.Cookies())
+
+	settings := config.GlobalSettings.Gate
+	for attempt := 1; det != nil && det.Passable() && settings.Retry && attempt <= settings.MaxRetries; attempt++ {
+		wait := det.RetryAfter
+		if wait <= 0 {
+			wait = settings.RetryWait
+		}
+		if settings.MaxWait > 0 && wait > settings.MaxWait {
+			wait = settings.MaxWait
+		}
+		report.Log("GEÇİT", fmt.Sprintf("%s bekleme sırasında, %s sonra tekrar denenecek (%d/%d).", url, wait, attempt, settings.MaxRetries))
+		time.Sleep(wait)
+
+		next, err := fetchPage(client, url, targetURL, profile, session)
+		if err != nil {
+			break
+		}
+		session.AddCookies(next.resp.Cookies())
+		*page = next
+		det = detectGate(next)
+	}
+
+	if det != nil && solver != nil {
+		cookies, err := solver.Solve(url, targetURL, session.UserAgent)
+		if err != nil {
+			report.Log("GEÇİT", fmt.Sprintf("%s geçidi etkileşimli olarak çözülemedi: %v", url, err))
+			return det
+		}
+		session.AddCookies(cookies)
+		if next, err := fetchPage(client, url, targetURL, profile, session); err == nil {
+			session.AddCookies(next.resp.Cookies())
+			*page = next
+			det = detectGate(next)
+		}
+		if det != nil {
+			report.Log("GEÇİT", fmt.Sprintf("%s geçidi tarayıcıda çözüldü ancak oturum çerezleriyle yapılan istek yine geçide düştü.", url))
+		}
+	}
+
+	if det == nil {
+		report.Log("GEÇİT", fmt.Sprintf("%s geçidi aşıldı, asıl sayfa ile devam ediliyor.", url))
+	}
+	return det
+}
@@ -0,0 +1,367 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+// HostStat sayfa render edilirken bağlantı kurulan bir sunucu
+type HostStat struct {
+	Host     string `json:"host"`
+	Requests int    `json:"requests"`
+	Failed   int    `json:"failed,omitempty"`
+	Bytes    int64  `json:"bytes"`
+	Onion    bool   `json:"onion"` // false ise açık ağ (clearnet) çağrısı
+}
+
+// HAR 1.2 yapıları (http://www.softwareishard.com/blog/har-12-spec/)
+type harLog struct {
+	Log harContent `json:"log"`
+}
+
+type harContent struct {
+	Version string      `json:"version"`
+	Creator harCreator  `json:"creator"`
+	Pages   []harPage   `json:"pages"`
+	Entries []*harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harPage struct {
+	StartedDateTime string         `json:"startedDateTime"`
+	ID              string         `json:"id"`
+	Title           string         `json:"title"`
+	PageTimings     harPageTimings `json:"pageTimings"`
+}
+
+type harPageTimings struct {
+	OnContentLoad float64 `json:"onContentLoad"`
+	OnLoad        float64 `json:"onLoad"`
+}
+
+type harEntry struct {
+	Pageref         string      `json:"pageref"`
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	ServerIPAddress string      `json:"serverIPAddress,omitempty"`
+	ResourceType    string      `json:"_resourceType,omitempty"`
+	Error           string      `json:"_error,omitempty"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int64          `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harBody        `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int64          `json:"bodySize"`
+}
+
+type harBody struct {
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType"`
+}
+
+type harTimings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+	SSL     float64 `json:"ssl"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// harRecorder chromedp ağ olaylarından HAR girdileri toplar
+type harRecorder struct {
+	mu      sync.Mutex
+	started time.Time
+	entries []*harEntry
+	pending map[network.RequestID]*harPending
+}
+
+type harPending struct {
+	entry  *harEntry
+	start  time.Time // Monotonik zaman (süre hesabı için)
+	timing *network.ResourceTiming
+}
+
+// newHARRecorder sekmenin ağ olaylarını dinlemeye başlar (chromedp Network alanını zaten açık tutar)
+func newHARRecorder(ctx context.Context) *harRecorder {
+	r := &harRecorder{
+		started: time.Now(),
+		pending: map[network.RequestID]*harPending{},
+	}
+
+	chromedp.ListenTarget(ctx, func(ev interface{}) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		switch e := ev.(type) {
+		case *network.EventRequestWillBeSent:
+			// Yönlendirmede aynı RequestID yeni istekle gelir; önceki girdi yönlendirme yanıtıyla kapanır
+			if p, ok := r.pending[e.RequestID]; ok && e.RedirectResponse != nil {
+				p.setResponse(e.RedirectResponse)
+				p.finish(e.Timestamp, int64(e.RedirectResponse.EncodedDataLength))
+			}
+			r.start(e)
+		case *network.EventResponseReceived:
+			if p, ok := r.pending[e.RequestID]; ok {
+				p.setResponse(e.Response)
+			}
+		case *network.EventLoadingFinished:
+			if p, ok := r.pending[e.RequestID]; ok {
+				p.finish(e.Timestamp, int64(e.EncodedDataLength))
+				delete(r.pending, e.RequestID)
+			}
+		case *network.EventLoadingFailed:
+			if p, ok := r.pending[e.RequestID]; ok {
+				p.entry.Error = e.ErrorText
+				if e.BlockedReason != "" {
+					p.entry.Error += " (" + e.BlockedReason.String() + ")"
+				}
+				p.finish(e.Timestamp, 0)
+				delete(r.pending, e.RequestID)
+			}
+		}
+	})
+	return r
+}
+
+func (r *harRecorder) start(e *network.EventRequestWillBeSent) {
+	if e.Request == nil {
+		return
+	}
+
+	startedAt := time.Now()
+	if e.WallTime != nil {
+		startedAt = e.WallTime.Time()
+	}
+	reqURL := e.Request.URL + e.Request.URLFragment
+
+	entry := &harEntry{
+		Pageref:         "page_1",
+		StartedDateTime: startedAt.Format(time.RFC3339Nano),
+		Request: harRequest{
+			Method:      e.Request.Method,
+			URL:         reqURL,
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(e.Request.Headers),
+			QueryString: harQuery(reqURL),
+			HeadersSize: -1,
+			BodySize:    0,
+		},
+		Response: harResponse{
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []harNameValue{},
+			Headers:     []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    -1,
+		},
+		Timings:      harTimings{Blocked: -1, DNS: -1, Connect: -1, SSL: -1},
+		ResourceType: e.Type.String(),
+	}
+	r.entries = append(r.entries, entry)
+
+	var start time.Time
+	if e.Timestamp != nil {
+		start = e.Timestamp.Time()
+	}
+	r.pending[e.RequestID] = &harPending{entry: entry, start: start}
+}
+
+func (p *harPending) setResponse(resp *network.Response) {
+	version := harHTTPVersion(resp.Protocol)
+	p.entry.Request.HTTPVersion = version
+	p.entry.Response.Status = resp.Status
+	p.entry.Response.StatusText = resp.StatusText
+	p.entry.Response.HTTPVersion = version
+	p.entry.Response.Headers = harHeaders(resp.Headers)
+	p.entry.Response.Content.MimeType = resp.MimeType
+	p.entry.Response.RedirectURL = headerValue(resp.Headers, "Location")
+	p.entry.ServerIPAddress = resp.RemoteIPAddress
+	if len(resp.RequestHeaders) > 0 {
+		// Gerçekte gönderilen başlıklar daha doğru
+		p.entry.Request.Headers = harHeaders(resp.RequestHeaders)
+	}
+	p.timing = resp.Timing
+}
+
+// finish toplam süreyi ve aşama sürelerini (ms) hesaplar
+func (p *harPending) finish(ts *cdp.MonotonicTime, encodedLength int64) {
+	p.entry.Response.BodySize = encodedLength
+	p.entry.Response.Content.Size = encodedLength
+
+	if ts != nil && !p.start.IsZero() {
+		p.entry.Time = float64(ts.Time().Sub(p.start).Microseconds()) / 1000
+	}
+
+	t := p.timing
+	if t == nil {
+		p.entry.Timings.Wait = p.entry.Time
+		return
+	}
+	span := func(start, end float64) float64 {
+		if start < 0 || end < start {
+			return -1
+		}
+		return end - start
+	}
+	p.entry.Timings.DNS = span(t.DNSStart, t.DNSEnd)
+	p.entry.Timings.Connect = span(t.ConnectStart, t.ConnectEnd)
+	p.entry.Timings.SSL = span(t.SslStart, t.SslEnd)
+	p.entry.Timings.Send = max(span(t.SendStart, t.SendEnd), 0)
+	p.entry.Timings.Wait = max(span(t.SendEnd, t.ReceiveHeadersEnd), 0)
+	if p.entry.Time > t.ReceiveHeadersEnd {
+		p.entry.Timings.Receive = p.entry.Time - t.ReceiveHeadersEnd
+	}
+}
+
+// JSON kaydı HAR 1.2 dosyası olarak döndürür
+func (r *harRecorder) JSON(pageURL string) ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	har := harLog{Log: harContent{
+		Version: "1.2",
+		Creator: harCreator{Name: "galileoff. OnionScraper", Version: "1.0"},
+		Pages: []harPage{{
+			StartedDateTime: r.started.Format(time.RFC3339Nano),
+			ID:              "page_1",
+			Title:           pageURL,
+			PageTimings:     harPageTimings{OnContentLoad: -1, OnLoad: -1},
+		}},
+		Entries: r.entries,
+	}}
+	if har.Log.Entries == nil {
+		har.Log.Entries = []*harEntry{}
+	}
+	return json.MarshalIndent(har, "", "  ")
+}
+
+// Hosts bağlantı kurulan sunucuların özetini istek sayısına göre sıralı döndürür
+func (r *harRecorder) Hosts() []HostStat {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := map[string]*HostStat{}
+	for _, e := range r.entries {
+		u, err := url.Parse(e.Request.URL)
+		if err != nil || u.Hostname() == "" {
+			continue // data:, blob: vb.
+		}
+		host := strings.ToLower(u.Hostname())
+		s, ok := stats[host]
+		if !ok {
+			s = &HostStat{Host: host, Onion: strings.HasSuffix(host, ".onion")}
+			stats[host] = s
+		}
+		s.Requests++
+		if e.Error != "" {
+			s.Failed++
+		}
+		if e.Response.BodySize > 0 {
+			s.Bytes += e.Response.BodySize
+		}
+	}
+
+	hosts := make([]HostStat, 0, len(stats))
+	for _, s := range stats {
+		hosts = append(hosts, *s)
+	}
+	sort.Slice(hosts, func(i, j int) bool {
+		if hosts[i].Requests != hosts[j].Requests {
+			return hosts[i].Requests > hosts[j].Requests
+		}
+		return hosts[i].Host < hosts[j].Host
+	})
+	return hosts
+}
+
+func harHeaders(h network.Headers) []harNameValue {
+	out := make([]harNameValue, 0, len(h))
+	for k, v := range h {
+		s, _ := v.(string)
+		// Chrome birden fazla değeri "\n" ile birleştirir
+		for _, part := range strings.Split(s, "\n") {
+			out = append(out, harNameValue{Name: k, Value: part})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+func headerValue(h network.Headers, name string) string {
+	for k, v := range h {
+		if strings.EqualFold(k, name) {
+			s, _ := v.(string)
+			return s
+		}
+	}
+	return ""
+}
+
+func harQuery(rawURL string) []harNameValue {
+	out := []harNameValue{}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return out
+	}
+	for k, values := range u.Query() {
+		for _, v := range values {
+			out = append(out, harNameValue{Name: k, Value: v})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+func harHTTPVersion(protocol string) string {
+	switch strings.ToLower(protocol) {
+	case "h2":
+		return "HTTP/2.0"
+	case "h3":
+		return "HTTP/3.0"
+	case "http/1.0":
+		return "HTTP/1.0"
+	default:
+		return "HTTP/1.1"
+	}
+}
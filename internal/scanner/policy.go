@@ -0,0 +1,123 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/chromedp/cdproto/browser"
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/emulation"
+	"github.com/chromedp/cdproto/fetch"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+
+	"galileoff-OnionScraper/internal/classifier"
+	"galileoff-OnionScraper/internal/config"
+	"galileoff-OnionScraper/internal/gate"
+	"galileoff-OnionScraper/internal/report"
+	"galileoff-OnionScraper/internal/utils"
+)
+
+// canvasBlockScript WebGL bağlamlarını ve canvas piksel okumasını sayfa scriptlerinden önce devre dışı bırakır
+const canvasBlockScript = `(() => {
+  const getContext = HTMLCanvasElement.prototype.getContext;
+  HTMLCanvasElement.prototype.getContext = function (type, ...args) {
+    if (/webgl/i.test(String(type))) return null;
+    return getContext.call(this, type, ...args);
+  };
+  const deny = () => { throw new DOMException("Canvas okuma engellendi", "SecurityError"); };
+  HTMLCanvasElement.prototype.toDataURL = deny;
+  HTMLCanvasElement.prototype.toBlob = deny;
+  CanvasRenderingContext2D.prototype.getImageData = deny;
+  if (window.OffscreenCanvas) OffscreenCanvas.prototype.convertToBlob = deny;
+})();`
+
+// policyActions sekme kısıtlamalarını sayfa açılmadan önce uygulayan adımlar
+func policyActions(url string, policy config.BrowserPolicy) []chromedp.Action {
+	var actions []chromedp.Action
+
+	if !policy.JavaScript {
+		actions = append(actions, emulation.SetScriptExecutionDisabled(true))
+	}
+	if policy.BlockCanvas && policy.JavaScript {
+		actions = append(actions, chromedp.ActionFunc(func(ctx context.Context) error {
+			_, err := page.AddScriptToEvaluateOnNewDocument(canvasBlockScript).Do(ctx)
+			return err
+		}))
+	}
+	if policy.BlockDownloads {
+		actions = append(actions, chromedp.ActionFunc(func(ctx context.Context) error {
+			params := browser.SetDownloadBehavior(browser.SetDownloadBehaviorBehaviorDeny)
+			// Sekme kendi izole tarayıcı bağlamında; sadece o bağlamın indirmeleri kapatılır
+			if c := chromedp.FromContext(ctx); c != nil && c.BrowserContextID != "" {
+				params = params.WithBrowserContextID(c.BrowserContextID)
+			}
+			return params.Do(ctx)
+		}))
+	}
+	if policy.Intercepts() {
+		actions = append(actions, fetch.Enable())
+	}
+	return actions
+}
+
+// interceptRequests Fetch ile duraklatılan istekleri politikaya göre düşürür veya devam ettirir.
+// Engellenen istekler HAR kaydında ERR_BLOCKED_BY_CLIENT hatasıyla görünür.
+func interceptRequests(ctx context.Context, url string, policy config.BrowserPolicy) *atomic.Int64 {
+	blocked := &atomic.Int64{}
+
+	chromedp.ListenTarget(ctx, func(ev interface{}) {
+		e, ok := ev.(*fetch.EventRequestPaused)
+		if !ok {
+			return
+		}
+
+		reason := blockReason(e, policy)
+		// Olay dinleyicisi içinde CDP komutu beklenemez; yanıt ayrı goroutine'den gönderilir
+		go func() {
+			c := chromedp.FromContext(ctx)
+			if c == nil || c.Target == nil {
+				return
+			}
+			execCtx := cdp.WithExecutor(ctx, c.Target)
+
+			var err error
+			if reason != "" {
+				blocked.Add(1)
+				report.Log("DEBUG", fmt.Sprintf("%s politikası gereği istek engellendi (%s): %s", url, reason, e.Request.URL))
+				err = fetch.FailRequest(e.RequestID, network.ErrorReasonBlockedByClient).Do(execCtx)
+			} else {
+				err = fetch.ContinueRequest(e.RequestID).Do(execCtx)
+			}
+			if err != nil && ctx.Err() == nil {
+				report.Log("DEBUG", fmt.Sprintf("%s için yakalanan istek sonuçlandırılamadı: %v", url, err))
+			}
+		}()
+	})
+	return blocked
+}
+
+// blockReason isteğin neden engellendiğini döndürür; engellenmeyecekse boş
+func blockReason(e *fetch.EventRequestPaused, policy config.BrowserPolicy) string {
+	switch {
+	case policy.BlockImages && e.ResourceType == network.ResourceTypeImage:
+		return "resim"
+	case policy.BlockMedia && e.ResourceType == network.ResourceTypeMedia:
+		return "medya"
+	case policy.BlockFonts && e.ResourceType == network.ResourceTypeFont:
+		return "font"
+	}
+	return ""
+}
+
+// preClassify kategoriye özel politika seçimi için ham gövdeyi tarayıcı adımından önce sınıflandırır;
+// geçit sayfaları gate kategorisinde sayılır
+func preClassify(url, content string, statusCode int, header http.Header) string {
+	if gate.Detect(content, classifier.VisibleText(content), statusCode, header) != nil {
+		return gate.CategoryID
+	}
+	return classifier.Analyze(content, url, len(utils.ExtractLinks(content))).CategoryID
+}
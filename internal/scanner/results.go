@@ -0,0 +1,38 @@
+package scanner
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// ResultsFile tarama sonuçlarının JSON olarak yazıldığı dosya adı
+const ResultsFile = "scan_result.json"
+
+// saveResults tüm hedeflerin sonuçlarını scan_result.log'un yanına JSON olarak yazar
+func saveResults(results []ScanResult, outputDir string) error {
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(outputDir, ResultsFile), data, 0644)
+}
+
+// Succeeded hedefe erişilip içeriğin işlendiğini belirtir
+func (r ScanResult) Succeeded() bool {
+	return r.Status == "SUCCESS"
+}
+
+// LoadResults daha önce kaydedilmiş scan_result.json dosyasını okur
+func LoadResults(outputDir string) ([]ScanResult, error) {
+	data, err := os.ReadFile(filepath.Join(outputDir, ResultsFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var results []ScanResult
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -3,78 +3,240 @@ package scanner
 import (
 	"context"
 	"fmt"
-	"os"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/chromedp/cdproto/emulation"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/cdproto/page"
 	"github.com/chromedp/chromedp"
+
+	"galileoff-OnionScraper/internal/config"
+	"galileoff-OnionScraper/internal/report"
 )
 
-// CaptureScreenshot belirtilen URL'in ekran görüntüsünü alır
-func CaptureScreenshot(url, proxyAddr string) ([]byte, error) {
-	// Edge tarayıcısının yolunu bulmaya çalış
-	edgePaths := []string{
-		`C:\Program Files (x86)\Microsoft\Edge\Application\msedge.exe`,
-		`C:\Program Files\Microsoft\Edge\Application\msedge.exe`,
+// PageCapture tarayıcıda açılan sayfadan alınanlar
+type PageCapture struct {
+	Screenshot []byte
+	DOM        string     // JavaScript çalıştıktan sonraki DOM (sadece istendiyse)
+	PDF        []byte     // Ayarlarda pdf açıksa
+	MHTML      []byte     // Ayarlarda mhtml açıksa
+	HAR        []byte     // Ayarlarda har açıksa
+	Hosts      []HostStat // Render sırasında bağlantı kurulan sunucular (har açıksa)
+}
+
+// CapturePage URL'i havuzdaki bir sekmede açar, ekran görüntüsünü ve istenirse render edilmiş DOM'u alır.
+// Bekleme stratejisi, görünüm alanı ve görüntü formatı settings.yaml'dan (hedefe özel ayarlar dahil) gelir.
+// category hedefin ön sınıflandırmasıdır (kategoriye özel ayarlar için, bilinmiyorsa boş).
+// session'da geçit çerezleri varsa sekme aynı çerezler ve User-Agent ile açılır.
+func CapturePage(pool *BrowserPool, url, category string, withDOM bool, session *Session) (PageCapture, error) {
+	var capture PageCapture
+	opts := config.GlobalSettings.Screenshot.For(url, category)
+
+	ctx, release, err := pool.Tab(opts.Timeout, opts.Policy.BlockClearnet)
+	if err != nil {
+		return capture, fmt.Errorf("tarayıcı sekmesi açılamadı: %v", err)
 	}
+	defer release()
 
-	var execPath string
-	for _, path := range edgePaths {
-		if _, err := os.Stat(path); err == nil {
-			execPath = path
-			break
-		}
+	// Eğer eksikse http:// önekini ekle
+	targetURL := url
+	if len(url) > 0 && url[:4] != "http" {
+		targetURL = "http://" + url
 	}
 
-	// Proxy ayarlarını yap
-	opts := append(chromedp.DefaultExecAllocatorOptions[:],
-		chromedp.ProxyServer("socks5://"+proxyAddr),
-		chromedp.Flag("headless", true),
-		chromedp.Flag("disable-gpu", true),
-		chromedp.Flag("no-sandbox", true),
-		// WebRTC ile IP sızıntısı önleme
-		chromedp.Flag("disable-webrtc", true),
-		chromedp.Flag("force-webrtc-ip-handling-policy", "disable_non_proxied_udp"),
-		// DNS sızıntı önleme
-		chromedp.Flag("host-resolver-rules", "MAP * ~NOTFOUND , EXCLUDE 127.0.0.1"),
-		// Gereksiz servisleri kapatma
-		chromedp.Flag("disable-sync", true),
-		chromedp.Flag("disable-background-networking", true),
-		chromedp.WindowSize(1280, 1024),
-	)
-
-	// Eğer Edge bulunduysa onu kullan
-	if execPath != "" {
-		opts = append(opts, chromedp.ExecPath(execPath))
+	width, height, mobile := opts.ViewportSize()
+	var viewportOpts []chromedp.EmulateViewportOption
+	if mobile {
+		viewportOpts = append(viewportOpts, chromedp.EmulateMobile)
 	}
 
-	allocCtx, cancel := chromedp.NewExecAllocator(context.Background(), opts...)
-	defer cancel()
+	actions := []chromedp.Action{
+		chromedp.EmulateViewport(int64(width), int64(height), viewportOpts...),
+	}
+	// Kısıtlamalar gezinmeden önce uygulanmalı
+	actions = append(actions, policyActions(url, opts.Policy)...)
+	if session != nil && len(session.Cookies) > 0 {
+		actions = append(actions, sessionAction(targetURL, session))
+	}
+	switch opts.Wait {
+	case config.WaitNetworkIdle:
+		idle := listenNetworkIdle(ctx)
+		actions = append(actions,
+			page.SetLifecycleEventsEnabled(true),
+			chromedp.Navigate(targetURL),
+			waitNetworkIdle(url, idle, opts.WaitTimeout),
+		)
+	case config.WaitSelector:
+		actions = append(actions, chromedp.Navigate(targetURL))
+		if opts.Selector != "" {
+			actions = append(actions, waitSelector(url, opts.Selector, opts.WaitTimeout))
+		}
+	default:
+		actions = append(actions, chromedp.Navigate(targetURL))
+	}
+	if opts.Delay > 0 {
+		actions = append(actions, chromedp.Sleep(opts.Delay))
+	}
+	if withDOM {
+		actions = append(actions, chromedp.OuterHTML("html", &capture.DOM, chromedp.ByQuery))
+	}
+	actions = append(actions, screenshotAction(&capture.Screenshot, opts))
+	if opts.PDF {
+		actions = append(actions, pdfAction(url, &capture.PDF))
+	}
+	if opts.MHTML {
+		actions = append(actions, mhtmlAction(url, &capture.MHTML))
+	}
 
-	// Zaman aşımı bağlamı oluştur (30 saniye iyi gibi)
-	ctx, cancel := context.WithTimeout(allocCtx, 30*time.Second)
-	defer cancel()
+	// Ağ kaydı sayfa açılmadan önce başlamalı
+	var har *harRecorder
+	if opts.HAR {
+		har = newHARRecorder(ctx)
+	}
 
-	ctx, cancel = chromedp.NewContext(ctx)
-	defer cancel()
+	var blocked *atomic.Int64
+	if opts.Policy.Intercepts() {
+		blocked = interceptRequests(ctx, url, opts.Policy)
+	}
 
-	var buf []byte
+	err = chromedp.Run(ctx, actions...)
 
-	// Eğer eksikse http:// önekini ekle
-	targetURL := url
-	if len(url) > 0 && url[:4] != "http" {
-		targetURL = "http://" + url
+	if blocked != nil && blocked.Load() > 0 {
+		report.Log("INFO", fmt.Sprintf("%s için tarayıcı politikası %d isteği engelledi.", url, blocked.Load()))
 	}
 
-	// Görevleri çalıştır
-	err := chromedp.Run(ctx,
-		chromedp.Navigate(targetURL),
-		chromedp.Sleep(2*time.Second), // Sayfanın tam yüklenmesi için sabır
-		chromedp.FullScreenshot(&buf, 90),
-	)
+	// Sayfa yarıda kalsa bile o ana kadarki istekler kanıt olarak saklanır
+	if har != nil {
+		capture.Hosts = har.Hosts()
+		if data, harErr := har.JSON(targetURL); harErr == nil {
+			capture.HAR = data
+		}
+	}
 
 	if err != nil {
-		return nil, fmt.Errorf("ekran görüntüsü alınamadı: %v", err)
+		return capture, fmt.Errorf("ekran görüntüsü alınamadı: %v", err)
 	}
 
-	return buf, nil
+	return capture, nil
+}
+
+// sessionAction geçit aşılırken alınan çerezleri sekmeye yükler; çerezler User-Agent'a bağlı olabileceği için o da eşitlenir
+func sessionAction(targetURL string, session *Session) chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		if session.UserAgent != "" {
+			if err := emulation.SetUserAgentOverride(session.UserAgent).Do(ctx); err != nil {
+				return err
+			}
+		}
+		var params []*network.CookieParam
+		for _, c := range session.Cookies {
+			params = append(params, &network.CookieParam{Name: c.Name, Value: c.Value, URL: targetURL})
+		}
+		return network.SetCookies(params).Do(ctx)
+	})
+}
+
+// listenNetworkIdle yeni gezinmeden sonra gelen ilk networkIdle olayında kapanan bir kanal döndürür
+func listenNetworkIdle(ctx context.Context) <-chan struct{} {
+	idle := make(chan struct{})
+	var once sync.Once
+	var mu sync.Mutex
+	armed := false
+
+	chromedp.ListenTarget(ctx, func(ev interface{}) {
+		e, ok := ev.(*page.EventLifecycleEvent)
+		if !ok {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		switch e.Name {
+		case "init":
+			// Boş sekmenin olaylarını saymamak için gezinme başladıktan sonra dinle
+			armed = true
+		case "networkIdle":
+			if armed {
+				once.Do(func() { close(idle) })
+			}
+		}
+	})
+	return idle
+}
+
+// waitNetworkIdle ağ trafiği durulana kadar en fazla limit süresi kadar bekler; süre dolarsa yine de devam eder
+func waitNetworkIdle(url string, idle <-chan struct{}, limit time.Duration) chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		select {
+		case <-idle:
+		case <-time.After(limit):
+			report.Log("DEBUG", fmt.Sprintf("%s için ağ trafiği %s içinde durulmadı, ekran görüntüsü yine de alınıyor.", url, limit))
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		return nil
+	})
+}
+
+// waitSelector seçici görünür olana kadar en fazla limit süresi kadar bekler; süre dolarsa yine de devam eder
+func waitSelector(url, selector string, limit time.Duration) chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		wctx, cancel := context.WithTimeout(ctx, limit)
+		defer cancel()
+
+		if err := chromedp.WaitVisible(selector, chromedp.ByQuery).Do(wctx); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			report.Log("DEBUG", fmt.Sprintf("%s için '%s' seçicisi %s içinde görünmedi, ekran görüntüsü yine de alınıyor.", url, selector, limit))
+		}
+		return nil
+	})
+}
+
+// screenshotAction seçilen format/kalite ile tam sayfa veya sadece görünen alanın görüntüsünü alır
+func screenshotAction(res *[]byte, opts config.ScreenshotOptions) chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		params := page.CaptureScreenshot().WithFromSurface(true)
+		if opts.Format == "jpeg" || opts.Format == "jpg" {
+			quality := min(max(opts.Quality, 1), 100)
+			params = params.WithFormat(page.CaptureScreenshotFormatJpeg).WithQuality(int64(quality))
+		} else {
+			params = params.WithFormat(page.CaptureScreenshotFormatPng)
+		}
+		if opts.FullPage {
+			params = params.WithCaptureBeyondViewport(true)
+		}
+
+		var err error
+		*res, err = params.Do(ctx)
+		return err
+	})
+}
+
+// pdfAction sayfanın yazdırma çıktısını (arka planlar dahil) alır; hata ekran görüntüsünü bozmasın diye sadece loglanır
+func pdfAction(url string, res *[]byte) chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		data, _, err := page.PrintToPDF().WithPrintBackground(true).Do(ctx)
+		if err != nil {
+			report.Log("ERROR", fmt.Sprintf("%s için PDF alınamadı: %v", url, err))
+			return ctx.Err()
+		}
+		*res = data
+		return nil
+	})
+}
+
+// mhtmlAction sayfayı kaynaklarıyla birlikte tek dosyalık MHTML arşivi olarak alır
+func mhtmlAction(url string, res *[]byte) chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		data, err := page.CaptureSnapshot().WithFormat(page.CaptureSnapshotFormatMhtml).Do(ctx)
+		if err != nil {
+			report.Log("ERROR", fmt.Sprintf("%s için MHTML alınamadı: %v", url, err))
+			return ctx.Err()
+		}
+		*res = []byte(data)
+		return nil
+	})
 }
@@ -0,0 +1,114 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+
+	"galileoff-OnionScraper/internal/config"
+	"galileoff-OnionScraper/internal/gate"
+	"galileoff-OnionScraper/internal/report"
+)
+
+// solvePollInterval görünür tarayıcıda geçidin aşılıp aşılmadığının kontrol aralığı
+const solvePollInterval = 2 * time.Second
+
+// GateSolver captcha geçitlerini analiste görünür bir tarayıcıda çözdürür.
+// Aynı anda tek pencere açılır; diğer worker'lar sırasını bekler.
+type GateSolver struct {
+	proxyAddr string
+	execPath  string
+	notify    func(msg string) // Analisti terminalde uyarmak için
+	mu        sync.Mutex
+}
+
+// NewGateSolver Tor proxy'si ve tarayıcı yolu ile etkileşimli çözücüyü hazırlar
+func NewGateSolver(proxyAddr, execPath string, notify func(msg string)) *GateSolver {
+	return &GateSolver{proxyAddr: proxyAddr, execPath: execPath, notify: notify}
+}
+
+// Solve sayfayı görünür tarayıcıda açar ve geçit kaybolana (veya başka bir sayfaya dönüşene) kadar bekler.
+// Analist captcha'yı çözünce tarayıcının oturum çerezleri döner; pencere kapatılırsa veya süre dolarsa hata döner.
+func (s *GateSolver) Solve(url, targetURL, userAgent string) ([]*http.Cookie, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	timeout := config.GlobalSettings.Gate.SolveTimeout
+	report.Log("GEÇİT", fmt.Sprintf("%s için görünür tarayıcı açılıyor (en fazla %s).", url, timeout))
+	s.notify(fmt.Sprintf("%s geçide takıldı. Açılan tarayıcı penceresinde doğrulamayı tamamlayın (%s süreniz var).", url, timeout))
+
+	// Başsız havuzla aynı bayraklar; sadece pencere görünür ve User-Agent HTTP istekleriyle aynı
+	opts := append(browserOptions(s.proxyAddr, s.execPath),
+		chromedp.Flag("headless", false),
+		chromedp.Flag("hide-scrollbars", false),
+		chromedp.Flag("mute-audio", false),
+	)
+	if userAgent != "" {
+		opts = append(opts, chromedp.UserAgent(userAgent))
+	}
+
+	allocCtx, allocCancel := chromedp.NewExecAllocator(context.Background(), opts...)
+	defer allocCancel()
+	ctx, cancel := chromedp.NewContext(allocCtx)
+	defer cancel()
+	ctx, timeoutCancel := context.WithTimeout(ctx, timeout)
+	defer timeoutCancel()
+
+	if err := chromedp.Run(ctx, chromedp.Navigate(targetURL)); err != nil {
+		return nil, fmt.Errorf("tarayıcı açılamadı: %v", err)
+	}
+
+	// İlk yüklemede görülen geçit; tarayıcıda kendiliğinden geçilen JS kontrollerinde nil olur
+	var first *gate.Detection
+	for polls := 0; ; polls++ {
+		var html, text string
+		err := chromedp.Run(ctx,
+			chromedp.OuterHTML("html", &html, chromedp.ByQuery),
+			chromedp.Evaluate(`document.body ? document.body.innerText : ""`, &text),
+		)
+		if err != nil {
+			if ctx.Err() == context.DeadlineExceeded {
+				return nil, fmt.Errorf("%s içinde çözülmedi", timeout)
+			}
+			return nil, fmt.Errorf("tarayıcı penceresi kapatıldı: %v", err)
+		}
+		// Asıl sitenin kendi captcha'lı giriş formu olabileceği için farklı bir sayfa da geçilmiş sayılır
+		current := gate.Detect(html, text, http.StatusOK, nil)
+		if polls == 0 {
+			first = current
+		}
+		if current == nil || !strings.EqualFold(current.Evidence, first.Evidence) {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%s içinde çözülmedi", timeout)
+		case <-time.After(solvePollInterval):
+		}
+	}
+
+	var cookies []*network.Cookie
+	if err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		var err error
+		cookies, err = network.GetCookies().WithURLs([]string{targetURL}).Do(ctx)
+		return err
+	})); err != nil {
+		return nil, fmt.Errorf("çerezler okunamadı: %v", err)
+	}
+
+	report.Log("GEÇİT", fmt.Sprintf("%s geçidi analist tarafından çözüldü, %d çerez alındı.", url, len(cookies)))
+	s.notify(fmt.Sprintf("%s doğrulandı, taramaya devam ediliyor.", url))
+
+	result := make([]*http.Cookie, 0, len(cookies))
+	for _, c := range cookies {
+		result = append(result, &http.Cookie{Name: c.Name, Value: c.Value, Path: c.Path, Domain: c.Domain})
+	}
+	return result, nil
+}
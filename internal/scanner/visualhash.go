@@ -0,0 +1,40 @@
+package scanner
+
+import (
+	"fmt"
+	"image"
+	"strconv"
+
+	"galileoff-OnionScraper/internal/imaging"
+	"galileoff-OnionScraper/internal/report"
+	"galileoff-OnionScraper/internal/similarity"
+)
+
+// computeVisualHash ekran görüntüsünün algısal hash'lerini hesaplar.
+// Boş veya tek renkli görüntüler (yüklenmeyen sayfalar) birbirine benzeyeceği için hashlenmez.
+func computeVisualHash(url string, img image.Image) *VisualHash {
+	dhash, ok := imaging.DHash(img)
+	if !ok {
+		report.Log("DEBUG", fmt.Sprintf("%s ekran görüntüsü tek renkli, görsel hash atlandı.", url))
+		return nil
+	}
+	phash, _ := imaging.PHash(img)
+
+	return &VisualHash{
+		DHash: fmt.Sprintf("%016x", dhash),
+		PHash: fmt.Sprintf("%016x", phash),
+	}
+}
+
+// visualItem sonucun görsel hash'lerini kümeleme girdisine çevirir
+func visualItem(result ScanResult) (similarity.VisualItem, bool) {
+	if result.VisualHash == nil {
+		return similarity.VisualItem{}, false
+	}
+	dhash, err1 := strconv.ParseUint(result.VisualHash.DHash, 16, 64)
+	phash, err2 := strconv.ParseUint(result.VisualHash.PHash, 16, 64)
+	if err1 != nil || err2 != nil {
+		return similarity.VisualItem{}, false
+	}
+	return similarity.VisualItem{URL: result.URL, DHash: dhash, PHash: phash}, true
+}
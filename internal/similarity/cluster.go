@@ -0,0 +1,141 @@
+package similarity
+
+import "sort"
+
+// Skor ağırlıkları: görünen metin yapıya göre daha belirleyici
+const (
+	textWeight       = 0.6
+	structWeight     = 0.4
+	faviconBonus     = 0.1
+	DefaultThreshold = 0.85
+)
+
+// Item kümelemeye giren tek bir hedef
+type Item struct {
+	URL         string
+	Signature   Signature
+	FaviconHash int32
+}
+
+// Member küme üyesi ve kümedeki en yakın komşusuna benzerliği
+type Member struct {
+	URL   string  `json:"url"`
+	Score float64 `json:"score"`
+}
+
+// Cluster "aynı site" olarak değerlendirilen hedef grubu
+type Cluster struct {
+	ID       int      `json:"id"`
+	Members  []Member `json:"members"`
+	AvgScore float64  `json:"avg_score"`
+}
+
+// Score iki hedefin benzerlik skorunu hesaplar (0-1)
+func Score(a, b Item) float64 {
+	var score float64
+	sameFavicon := a.FaviconHash != 0 && a.FaviconHash == b.FaviconHash
+
+	// Metni olmayan sayfalar (boş/JS sayfası) yapı olarak hep birbirine benzer;
+	// sadece favicon da aynıysa yapıya bakılır, aksi halde eşleşme sayılmaz
+	if a.Signature.TextHash == 0 || b.Signature.TextHash == 0 {
+		if !sameFavicon {
+			return 0
+		}
+		score = HashSimilarity(a.Signature.StructHash, b.Signature.StructHash)
+	} else {
+		score = textWeight*HashSimilarity(a.Signature.TextHash, b.Signature.TextHash) +
+			structWeight*HashSimilarity(a.Signature.StructHash, b.Signature.StructHash)
+	}
+
+	if sameFavicon {
+		score += faviconBonus
+	}
+	if score > 1 {
+		score = 1
+	}
+	return score
+}
+
+// Clusters eşik üzerindeki hedefleri birleştirerek kümeler (tek üyeli gruplar döndürülmez)
+func Clusters(items []Item, threshold float64) []Cluster {
+	return group(len(items),
+		func(i int) string { return items[i].URL },
+		func(i, j int) float64 { return Score(items[i], items[j]) },
+		threshold)
+}
+
+// group skoru eşiğin üzerindeki çiftleri union-find ile birleştirir
+func group(n int, urlOf func(int) string, score func(i, j int) float64, threshold float64) []Cluster {
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = i
+	}
+
+	var find func(int) int
+	find = func(x int) int {
+		if parent[x] != x {
+			parent[x] = find(parent[x])
+		}
+		return parent[x]
+	}
+
+	// Her hedefin kümedeki en iyi eşleşmesi
+	best := make([]float64, n)
+
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			s := score(i, j)
+			if s < threshold {
+				continue
+			}
+			if s > best[i] {
+				best[i] = s
+			}
+			if s > best[j] {
+				best[j] = s
+			}
+			parent[find(i)] = find(j)
+		}
+	}
+
+	groups := make(map[int][]int)
+	for i := 0; i < n; i++ {
+		root := find(i)
+		groups[root] = append(groups[root], i)
+	}
+
+	var clusters []Cluster
+	for _, idxs := range groups {
+		if len(idxs) < 2 {
+			continue
+		}
+
+		c := Cluster{}
+		var total float64
+		pairs := 0
+		for a := 0; a < len(idxs); a++ {
+			c.Members = append(c.Members, Member{URL: urlOf(idxs[a]), Score: best[idxs[a]]})
+			for b := a + 1; b < len(idxs); b++ {
+				total += score(idxs[a], idxs[b])
+				pairs++
+			}
+		}
+		c.AvgScore = total / float64(pairs)
+
+		sort.Slice(c.Members, func(x, y int) bool { return c.Members[x].URL < c.Members[y].URL })
+		clusters = append(clusters, c)
+	}
+
+	// Büyük kümeler önce
+	sort.Slice(clusters, func(x, y int) bool {
+		if len(clusters[x].Members) != len(clusters[y].Members) {
+			return len(clusters[x].Members) > len(clusters[y].Members)
+		}
+		return clusters[x].Members[0].URL < clusters[y].Members[0].URL
+	})
+	for i := range clusters {
+		clusters[i].ID = i + 1
+	}
+
+	return clusters
+}
@@ -0,0 +1,106 @@
+package similarity
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// shingleSize metin SimHash'inde kullanılan kelime grubu uzunluğu
+const shingleSize = 3
+
+// Signature bir sayfanın benzerlik imzası (64 bit SimHash'ler)
+type Signature struct {
+	TextHash   uint64 `json:"text_simhash"`
+	StructHash uint64 `json:"struct_simhash"`
+}
+
+// Compute görünen metin ve HTML iskeletinden benzerlik imzası üretir
+func Compute(visibleText, htmlContent string) Signature {
+	var sig Signature
+	sig.TextHash = SimHash(textShingles(visibleText))
+
+	if doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent)); err == nil {
+		sig.StructHash = SimHash(tagPaths(doc))
+	}
+	return sig
+}
+
+// SimHash özellik listesinden 64 bitlik SimHash hesaplar (tüm özellikler eşit ağırlıklı)
+func SimHash(features []string) uint64 {
+	if len(features) == 0 {
+		return 0
+	}
+
+	var weights [64]int
+	for _, f := range features {
+		h := fnv.New64a()
+		h.Write([]byte(f))
+		sum := h.Sum64()
+		for i := 0; i < 64; i++ {
+			if sum&(1<<uint(i)) != 0 {
+				weights[i]++
+			} else {
+				weights[i]--
+			}
+		}
+	}
+
+	var out uint64
+	for i := 0; i < 64; i++ {
+		if weights[i] > 0 {
+			out |= 1 << uint(i)
+		}
+	}
+	return out
+}
+
+// HashSimilarity iki SimHash arasındaki benzerliği 0-1 aralığında döndürür
+func HashSimilarity(a, b uint64) float64 {
+	return 1 - float64(bits.OnesCount64(a^b))/64
+}
+
+// textShingles metni küçük harfe çevirip ardışık kelime gruplarına böler
+func textShingles(text string) []string {
+	words := strings.Fields(strings.ToLower(text))
+	if len(words) < shingleSize {
+		if len(words) == 0 {
+			return nil
+		}
+		return []string{strings.Join(words, " ")}
+	}
+
+	shingles := make([]string, 0, len(words)-shingleSize+1)
+	for i := 0; i+shingleSize <= len(words); i++ {
+		shingles = append(shingles, strings.Join(words[i:i+shingleSize], " "))
+	}
+	return shingles
+}
+
+// tagPaths her eleman için en fazla 3 seviyelik etiket yolunu (div>ul>li) çıkarır
+func tagPaths(doc *goquery.Document) []string {
+	var paths []string
+
+	var walk func(n *html.Node, stack []string)
+	walk = func(n *html.Node, stack []string) {
+		if n.Type == html.ElementNode {
+			stack = append(stack, n.Data)
+			start := len(stack) - 3
+			if start < 0 {
+				start = 0
+			}
+			paths = append(paths, strings.Join(stack[start:], ">"))
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c, stack)
+		}
+	}
+
+	for _, n := range doc.Nodes {
+		walk(n, nil)
+	}
+	return paths
+}
@@ -0,0 +1,26 @@
+package similarity
+
+import "galileoff-OnionScraper/internal/imaging"
+
+// VisualItem ekran görüntüsü hash'leri ile kümelemeye giren hedef
+type VisualItem struct {
+	URL   string
+	DHash uint64
+	PHash uint64
+}
+
+// VisualScore iki görüntünün benzerliği (0-1). İki hash'ten uzak olanı esas alınır;
+// böylece sadece düzeni veya sadece renk dağılımı benzeyen sayfalar eşleşmez.
+func VisualScore(a, b VisualItem) float64 {
+	d := max(imaging.Hamming(a.DHash, b.DHash), imaging.Hamming(a.PHash, b.PHash))
+	return 1 - float64(d)/64
+}
+
+// VisualClusters Hamming mesafesi maxDistance'ı aşmayan ekran görüntülerini kümeler
+// (farklı kaynak koduyla aynı şablonu kullanan siteleri bulmak için)
+func VisualClusters(items []VisualItem, maxDistance int) []Cluster {
+	return group(len(items),
+		func(i int) string { return items[i].URL },
+		func(i, j int) float64 { return VisualScore(items[i], items[j]) },
+		1-float64(maxDistance)/64)
+}
@@ -0,0 +1,249 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite" // Saf Go SQLite sürücüsü (cgo gerekmez)
+
+	"galileoff-OnionScraper/internal/scanner"
+)
+
+// timeFormat veritabanındaki zaman damgaları: UTC'de RFC 3339 ("2025-01-31T11:05:00Z").
+// Farklı saat dilimlerindeki taramalar doğru sıralanır ve SQLite tarih fonksiyonları okuyabilir.
+const timeFormat = time.RFC3339
+
+// timestamp zamanı veritabanı biçimine çevirir
+func timestamp(t time.Time) string {
+	return t.UTC().Format(timeFormat)
+}
+
+const schema = `
+CREATE TABLE IF NOT EXISTS scans (
+	id           INTEGER PRIMARY KEY AUTOINCREMENT,
+	target_file  TEXT NOT NULL,
+	output_dir   TEXT NOT NULL,
+	started_at   TEXT NOT NULL,
+	finished_at  TEXT NOT NULL,
+	worker_count INTEGER NOT NULL,
+	total        INTEGER NOT NULL,
+	success      INTEGER NOT NULL,
+	failed       INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS targets (
+	id         INTEGER PRIMARY KEY AUTOINCREMENT,
+	url        TEXT NOT NULL UNIQUE,
+	host       TEXT NOT NULL,
+	first_seen TEXT NOT NULL,
+	last_seen  TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS fetches (
+	id              INTEGER PRIMARY KEY AUTOINCREMENT,
+	scan_id         INTEGER NOT NULL REFERENCES scans(id),
+	target_id       INTEGER NOT NULL REFERENCES targets(id),
+	fetched_at      TEXT NOT NULL,
+	status          TEXT NOT NULL,
+	status_code     INTEGER,
+	error           TEXT,
+	title           TEXT,
+	user_agent      TEXT,
+	html_file       TEXT,
+	screenshot_file TEXT,
+	fetch_ms        INTEGER,
+	screenshot_ms   INTEGER,
+	favicon_hash    INTEGER,
+	title_hash      TEXT,
+	header_hash     TEXT,
+	dom_hash        TEXT
+);
+
+CREATE TABLE IF NOT EXISTS classifications (
+	id            INTEGER PRIMARY KEY AUTOINCREMENT,
+	fetch_id      INTEGER NOT NULL REFERENCES fetches(id),
+	target_id     INTEGER NOT NULL REFERENCES targets(id),
+	classified_at TEXT NOT NULL,
+	category_id   TEXT NOT NULL,
+	tag           TEXT NOT NULL,
+	score         INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS links (
+	id        INTEGER PRIMARY KEY AUTOINCREMENT,
+	fetch_id  INTEGER NOT NULL REFERENCES fetches(id),
+	target_id INTEGER NOT NULL REFERENCES targets(id),
+	url       TEXT NOT NULL,
+	text      TEXT
+);
+
+CREATE TABLE IF NOT EXISTS entities (
+	id        INTEGER PRIMARY KEY AUTOINCREMENT,
+	fetch_id  INTEGER NOT NULL REFERENCES fetches(id),
+	target_id INTEGER NOT NULL REFERENCES targets(id),
+	seen_at   TEXT NOT NULL,
+	source    TEXT NOT NULL, -- entity, opsec, victim
+	kind      TEXT NOT NULL,
+	value     TEXT NOT NULL,
+	evidence  TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_fetches_target ON fetches(target_id);
+CREATE INDEX IF NOT EXISTS idx_class_target ON classifications(target_id, category_id);
+CREATE INDEX IF NOT EXISTS idx_links_target ON links(target_id);
+CREATE INDEX IF NOT EXISTS idx_entities_value ON entities(kind, value);
+`
+
+// Store taramalar arası kalıcı sonuç deposu
+type Store struct {
+	db *sql.DB
+}
+
+// ScanInfo bir çalıştırmanın genel bilgileri
+type ScanInfo struct {
+	TargetFile  string
+	OutputDir   string
+	StartedAt   time.Time
+	FinishedAt  time.Time
+	WorkerCount int
+	Success     int
+	Failed      int
+}
+
+// Open veritabanını açar, yoksa oluşturur ve şemayı hazırlar
+func Open(path string) (*Store, error) {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("veritabanı klasörü oluşturulamadı: %v", err)
+		}
+	}
+
+	db, err := sql.Open("sqlite", path+"?_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("şema oluşturulamadı: %v", err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close veritabanını kapatır
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// SaveScan bir taramanın tüm sonuçlarını tek transaction içinde kaydeder ve tarama ID'sini döndürür
+func (s *Store) SaveScan(info ScanInfo, results []scanner.ScanResult) (int64, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`INSERT INTO scans (target_file, output_dir, started_at, finished_at, worker_count, total, success, failed)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		info.TargetFile, info.OutputDir, timestamp(info.StartedAt), timestamp(info.FinishedAt),
+		info.WorkerCount, len(results), info.Success, info.Failed)
+	if err != nil {
+		return 0, err
+	}
+	scanID, _ := res.LastInsertId()
+
+	for _, r := range results {
+		if err := saveResult(tx, scanID, r); err != nil {
+			return 0, fmt.Errorf("%s kaydedilemedi: %v", r.URL, err)
+		}
+	}
+
+	return scanID, tx.Commit()
+}
+
+// saveResult tek hedefin fetch, sınıflandırma, link ve varlık kayıtlarını yazar
+func saveResult(tx *sql.Tx, scanID int64, r scanner.ScanResult) error {
+	seenAt := r.ScannedAt
+	if seenAt.IsZero() {
+		seenAt = time.Now()
+	}
+	ts := timestamp(seenAt)
+
+	targetID, err := upsertTarget(tx, r.URL, ts)
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.Exec(`INSERT INTO fetches (scan_id, target_id, fetched_at, status, status_code, error, title, user_agent,
+		html_file, screenshot_file, fetch_ms, screenshot_ms, favicon_hash, title_hash, header_hash, dom_hash)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		scanID, targetID, ts, r.Status, r.StatusCode, r.ErrorText, r.Page.Title, r.UsedUA,
+		r.HTMLFile, r.Screenshot, r.Timings.FetchMS, r.Timings.ScreenshotMS,
+		r.Fingerprints.FaviconHash, r.Fingerprints.TitleHash, r.Fingerprints.HeaderHash, r.Fingerprints.DOMHash)
+	if err != nil {
+		return err
+	}
+	fetchID, _ := res.LastInsertId()
+
+	if !r.Succeeded() {
+		return nil
+	}
+
+	if r.CategoryID != "" {
+		if _, err := tx.Exec(`INSERT INTO classifications (fetch_id, target_id, classified_at, category_id, tag, score) VALUES (?, ?, ?, ?, ?, ?)`,
+			fetchID, targetID, ts, r.CategoryID, r.Tag, r.Score); err != nil {
+			return err
+		}
+	}
+
+	for _, l := range r.Links {
+		if _, err := tx.Exec(`INSERT INTO links (fetch_id, target_id, url, text) VALUES (?, ?, ?, ?)`,
+			fetchID, targetID, l.URL, l.Text); err != nil {
+			return err
+		}
+	}
+
+	insertEntity := func(source, kind, value, evidence string) error {
+		_, err := tx.Exec(`INSERT INTO entities (fetch_id, target_id, seen_at, source, kind, value, evidence) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			fetchID, targetID, ts, source, kind, value, evidence)
+		return err
+	}
+	for _, e := range r.Entities {
+		if err := insertEntity("entity", e.Type, e.Value, ""); err != nil {
+			return err
+		}
+	}
+	for _, f := range r.Opsec {
+		if err := insertEntity("opsec", f.Type, f.Value, f.Evidence); err != nil {
+			return err
+		}
+	}
+	for _, v := range r.Victims {
+		if err := insertEntity("victim", v.Group, v.Name, v.Domain); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// upsertTarget hedefi ekler veya last_seen alanını günceller
+func upsertTarget(tx *sql.Tx, rawURL, ts string) (int64, error) {
+	host := rawURL
+	if u, err := url.Parse(rawURL); err == nil && u.Hostname() != "" {
+		host = strings.ToLower(u.Hostname())
+	}
+
+	if _, err := tx.Exec(`INSERT INTO targets (url, host, first_seen, last_seen) VALUES (?, ?, ?, ?)
+		ON CONFLICT(url) DO UPDATE SET last_seen = excluded.last_seen`, rawURL, host, ts, ts); err != nil {
+		return 0, err
+	}
+
+	var id int64
+	err := tx.QueryRow(`SELECT id FROM targets WHERE url = ?`, rawURL).Scan(&id)
+	return id, err
+}
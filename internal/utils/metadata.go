@@ -0,0 +1,140 @@
+package utils
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// securityHeaders envanterde kontrol edilen güvenlik başlıkları
+var securityHeaders = []string{
+	"Content-Security-Policy",
+	"Strict-Transport-Security",
+	"X-Frame-Options",
+	"X-Content-Type-Options",
+	"Referrer-Policy",
+	"Permissions-Policy",
+	"Cross-Origin-Opener-Policy",
+	"Cross-Origin-Resource-Policy",
+	"Cross-Origin-Embedder-Policy",
+	"X-XSS-Protection",
+}
+
+// PageInfo sayfanın dışarıya açtığı meta veriler ve form envanteri
+type PageInfo struct {
+	Title           string            `json:"title,omitempty"`
+	Description     string            `json:"description,omitempty"`
+	Keywords        string            `json:"keywords,omitempty"`
+	Generator       string            `json:"generator,omitempty"`
+	Cookies         []string          `json:"cookies,omitempty"` // Sadece isimler
+	SecurityHeaders map[string]string `json:"security_headers,omitempty"`
+	Forms           []FormData        `json:"forms,omitempty"`
+}
+
+// FormData sayfadaki tek bir formun özeti
+type FormData struct {
+	Action      string      `json:"action"`
+	Method      string      `json:"method"`
+	Inputs      []FormInput `json:"inputs"`
+	HasPassword bool        `json:"has_password"`
+	HasUpload   bool        `json:"has_upload"`
+}
+
+// FormInput formdaki alanın adı ve tipi
+type FormInput struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// ExtractPageInfo HTML ve response header'larından sayfa envanterini çıkarır
+func ExtractPageInfo(htmlContent string, header http.Header) PageInfo {
+	info := PageInfo{
+		SecurityHeaders: map[string]string{},
+	}
+
+	// Çerezler (değerleri değil sadece isimleri)
+	for _, c := range (&http.Response{Header: header}).Cookies() {
+		info.Cookies = append(info.Cookies, c.Name)
+	}
+
+	for _, name := range securityHeaders {
+		if v := header.Get(name); v != "" {
+			info.SecurityHeaders[name] = v
+		}
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+	if err != nil {
+		return info
+	}
+
+	info.Title = cleanSpace(doc.Find("title").First().Text())
+	info.Description = metaContent(doc, "description")
+	info.Keywords = metaContent(doc, "keywords")
+	info.Generator = metaContent(doc, "generator")
+
+	doc.Find("form").Each(func(i int, s *goquery.Selection) {
+		action, _ := s.Attr("action")
+		method, _ := s.Attr("method")
+		method = strings.ToUpper(strings.TrimSpace(method))
+		if method == "" {
+			method = "GET"
+		}
+
+		form := FormData{
+			Action: strings.TrimSpace(action),
+			Method: method,
+		}
+
+		s.Find("input, select, textarea, button").Each(func(j int, in *goquery.Selection) {
+			name, _ := in.Attr("name")
+			inputType := goquery.NodeName(in)
+			if inputType == "input" || inputType == "button" {
+				if t, ok := in.Attr("type"); ok && strings.TrimSpace(t) != "" {
+					inputType = strings.ToLower(strings.TrimSpace(t))
+				} else if inputType == "input" {
+					inputType = "text"
+				} else {
+					inputType = "submit"
+				}
+			}
+
+			// İsimsiz butonlar envantere bir şey katmıyor
+			if name == "" && (inputType == "submit" || inputType == "button") {
+				return
+			}
+
+			switch inputType {
+			case "password":
+				form.HasPassword = true
+			case "file":
+				form.HasUpload = true
+			}
+			form.Inputs = append(form.Inputs, FormInput{Name: name, Type: inputType})
+		})
+
+		info.Forms = append(info.Forms, form)
+	})
+
+	return info
+}
+
+// metaContent <meta name="..."> içeriğini döndürür (isim büyük/küçük harf duyarsız)
+func metaContent(doc *goquery.Document, name string) string {
+	content := ""
+	doc.Find("meta[name]").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		n, _ := s.Attr("name")
+		if strings.EqualFold(strings.TrimSpace(n), name) {
+			content, _ = s.Attr("content")
+			return false
+		}
+		return true
+	})
+	return cleanSpace(content)
+}
+
+// cleanSpace fazla boşlukları tek boşluğa indirir
+func cleanSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -8,8 +8,8 @@ import (
 
 // LinkData linkin URLini ve görünen metnini (anchor text) tutar
 type LinkData struct {
-	URL  string
-	Text string
+	URL  string `json:"url"`
+	Text string `json:"text,omitempty"`
 }
 
 // ExtractLinks HTML içeriğinden tüm linkleri ve metinlerini çeker
@@ -33,3 +33,8 @@ func ExtractLinks(htmlContent string) []LinkData {
 
 	return links
 }
+
+// Defang .onion adreslerini tıklanamaz/çözümlenemez hale getirir (.onion -> [.]onion)
+func Defang(s string) string {
+	return strings.ReplaceAll(s, ".onion", "[.]onion")
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io/fs"
 	"os"
@@ -10,9 +11,12 @@ import (
 	"time"
 
 	"galileoff-OnionScraper/internal/config"
+	"galileoff-OnionScraper/internal/diff"
+	"galileoff-OnionScraper/internal/export"
 	"galileoff-OnionScraper/internal/network"
 	"galileoff-OnionScraper/internal/report"
 	"galileoff-OnionScraper/internal/scanner"
+	"galileoff-OnionScraper/internal/store"
 	"galileoff-OnionScraper/internal/ui"
 	"galileoff-OnionScraper/internal/utils"
 )
@@ -22,6 +26,22 @@ func main() {
 	ui.PrintRandomBanner()
 	ui.PrintBoxedTitle("galileoff. ONION SCRAPER", "Harikulade Tor Ağı Veri Kazıyıcısı")
 
+	// Program Ayarları (dosya yoksa varsayılanlar kullanılır).
+	// Hatalı dosyada devam edilmez: varsayılanlara düşmek JavaScript/açık ağ kısıtlamalarını sessizce kaldırır.
+	if err := config.LoadSettings("config/settings.yaml"); err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			ui.PrintError(fmt.Sprintf("settings.yaml hatalı, tarama başlatılmadı: %v", err))
+			os.Exit(1)
+		}
+		ui.PrintInfo("settings.yaml bulunamadı, varsayılan ayarlar kullanılıyor.")
+	}
+
+	// Alt komut: iki tarama çıktısını karşılaştır (Tor bağlantısı gerekmez)
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		runDiff(os.Args[2:])
+		return
+	}
+
 	// IP Kontrolü
 	// Kullanıcı daha menüye girmeden Tor'a bağlı mı görsün diye.
 	ui.PrintInfo("IP Adresi ve Tor Bağlantısı kontrol ediliyor...")
@@ -37,6 +57,22 @@ func main() {
 		}
 	}
 
+	// Ekran görüntüsü tarayıcısı kontrolü
+	if browser, err := scanner.ResolveBrowser(); err != nil {
+		ui.PrintWarningBox([]string{
+			"EKRAN GÖRÜNTÜSÜ ALINAMAYACAK",
+			"Chromium, Chrome, Edge veya Brave bulunamadı.",
+			"Tarayıcı kurun veya settings.yaml içinde browser.path ayarlayın.",
+			fmt.Sprintf("(Hata: %v)", err),
+		})
+	} else {
+		version := browser.Version
+		if version == "" {
+			version = browser.Name
+		}
+		ui.PrintSuccess(fmt.Sprintf("Ekran Görüntüsü Tarayıcısı: %s (%s)", version, browser.Path))
+	}
+
 	for {
 		// Dosya Seçimi (İnteraktif)
 		targetFile := selectTargetFile()
@@ -76,19 +112,20 @@ func main() {
 
 		// Klasör Hazırlığı
 		// targets.yaml -> targets klasörü
+		// Her çalıştırma zaman damgalı alt klasöre yazılır: targets/2025-01-31_14-05-00
 		baseName := filepath.Base(targetFile)
 		ext := filepath.Ext(baseName)
-		outputDir := strings.TrimSuffix(baseName, ext)
+		baseDir := strings.TrimSuffix(baseName, ext)
 
-		// Klasörü temizle/oluştur
-		ui.PrintInfo(fmt.Sprintf("Çıktı klasörü hazırlanıyor: %s", outputDir))
-		if err := report.PrepareOutputDirectory(outputDir); err != nil {
+		outputDir, err := report.PrepareRunDirectory(baseDir)
+		if err != nil {
 			ui.PrintError(fmt.Sprintf("Klasör hatası: %v", err))
 			if !ui.AskForNewScan() {
 				break
 			}
 			continue
 		}
+		ui.PrintInfo(fmt.Sprintf("Çıktı klasörü hazırlandı: %s", outputDir))
 
 		// Loglayıcıyı Başlat
 		if err := report.InitLogger("scan_result.log", outputDir); err != nil {
@@ -102,6 +139,15 @@ func main() {
 		// Worker(köle) Sayısını Seç
 		workerCount := ui.GetWorkerCount()
 
+		// Kanıt Arşivini (WARC) Başlat
+		if err := report.InitWARC(outputDir, map[string]string{
+			"description": "Tor ağı taraması (" + filepath.Base(targetFile) + ")",
+			"isPartOf":    outputDir,
+			"workers":     fmt.Sprint(workerCount),
+		}); err != nil {
+			ui.PrintError(fmt.Sprintf("WARC arşivi oluşturulamadı: %v", err))
+		}
+
 		// İstatistikleri Takip Et
 		startTime := time.Now()
 
@@ -109,7 +155,28 @@ func main() {
 		report.LogHeader(filepath.Base(targetFile), workerCount)
 
 		// Tarayıcıyı Başlat
-		successCount, failCount, totalLinks := scanner.StartScan(targets, workerCount, outputDir)
+		successCount, failCount, totalLinks, results := scanner.StartScan(targets, workerCount, outputDir)
+
+		// Sonuçları dışa aktar (STIX vb.)
+		exportResults(results, outputDir)
+
+		// Ekran görüntülerini önceki çalıştırmayla karşılaştır (defacement, el koyma afişi vb.)
+		if config.GlobalSettings.VisualDiff.Enabled {
+			compareScreenshots(outputDir, results)
+		}
+
+		// Kalıcı veritabanına kaydet (ayarlarda açıksa)
+		if config.GlobalSettings.Store.Enabled {
+			saveToStore(store.ScanInfo{
+				TargetFile:  targetFile,
+				OutputDir:   outputDir,
+				StartedAt:   startTime,
+				FinishedAt:  time.Now(),
+				WorkerCount: workerCount,
+				Success:     successCount,
+				Failed:      failCount,
+			}, results)
+		}
 
 		duration := time.Since(startTime)
 
@@ -117,7 +184,10 @@ func main() {
 		_, totalSizeStr := analyzeOutput(outputDir)
 		report.LogFooter(len(targets), successCount, failCount, totalLinks, duration, totalSizeStr)
 
-		report.Close() // Log dosyasını kapat
+		report.Close() // Log ve WARC dosyalarını kapat
+
+		// latest işaretçisini güncelle ve eski çalıştırmaları temizle
+		finishRun(baseDir, outputDir)
 
 		// Sonuç Analizi ve Raporlama
 		files, totalSize := analyzeOutput(outputDir)
@@ -211,6 +281,121 @@ func selectUserAgentFile() string {
 	}
 }
 
+// exportResults tarama sonuçlarını CTI formatlarına çevirir, hata olursa sadece raporlar
+func exportResults(results []scanner.ScanResult, outputDir string) {
+	exporters := []struct {
+		name string
+		fn   func([]scanner.ScanResult, string) error
+	}{
+		{"Özet Tablo (" + export.SummaryCSVFile + ")", export.SaveSummary},
+		{"STIX 2.1 Paketi (" + export.STIXFile + ")", export.SaveSTIX},
+		{"MISP Event (" + export.MISPFile + ")", export.SaveMISP},
+		{"HTML Rapor (" + export.HTMLReportFile + ")", export.SaveHTMLReport},
+		{"Link Grafiği (GraphML/GEXF/DOT)", export.SaveLinkGraph},
+	}
+
+	for _, e := range exporters {
+		if err := e.fn(results, outputDir); err != nil {
+			ui.PrintError(fmt.Sprintf("%s oluşturulamadı: %v", e.name, err))
+			report.Log("ERROR", fmt.Sprintf("%s oluşturulamadı: %v", e.name, err))
+			continue
+		}
+		ui.PrintSuccess(fmt.Sprintf("%s oluşturuldu.", e.name))
+		report.Log("INFO", fmt.Sprintf("%s oluşturuldu.", e.name))
+	}
+}
+
+// compareScreenshots yeni ekran görüntülerini önceki çalıştırmanın görüntüleriyle karşılaştırır
+func compareScreenshots(outputDir string, results []scanner.ScanResult) {
+	prevDir, ok := report.PreviousRun(outputDir)
+	if !ok {
+		return
+	}
+
+	threshold := config.GlobalSettings.VisualDiff.Threshold
+	changes, err := diff.CompareScreenshots(prevDir, outputDir, results, threshold)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Görsel karşılaştırma başarısız: %v", err))
+		report.Log("ERROR", fmt.Sprintf("Görsel karşılaştırma başarısız: %v", err))
+		return
+	}
+
+	for _, c := range changes {
+		report.Log("WARNING", fmt.Sprintf("Görünüm değişti (%%%.1f): %s -> %s", c.Score, c.URL, c.DiffFile))
+	}
+	ui.PrintSuccess(fmt.Sprintf("Görsel karşılaştırma tamamlandı: %d hedefin görünümü değişti (%s).", len(changes), diff.VisualReportFile))
+}
+
+// finishRun latest işaretçisini yeni çalıştırmaya çevirir ve saklama politikasını uygular
+func finishRun(baseDir, runDir string) {
+	if err := report.UpdateLatest(baseDir, runDir); err != nil {
+		ui.PrintError(fmt.Sprintf("latest işaretçisi güncellenemedi: %v", err))
+	}
+
+	retention := config.GlobalSettings.Retention
+	removed, err := report.ApplyRetention(baseDir, retention.KeepLast, retention.MaxAgeDays, runDir)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Eski çalıştırmalar temizlenemedi: %v", err))
+	}
+	for _, r := range removed {
+		ui.PrintInfo("Saklama politikası gereği silindi: " + r)
+	}
+}
+
+// runDiff iki tarama klasörünü karşılaştırır ve raporu yeni klasöre kaydeder
+func runDiff(args []string) {
+	var oldDir, newDir string
+	switch len(args) {
+	case 1:
+		// Tek klasör verilirse son iki çalıştırma karşılaştırılır
+		runs, err := report.ListRuns(args[0])
+		if err != nil || len(runs) < 2 {
+			ui.PrintError("Karşılaştırma için klasörde en az iki çalıştırma olmalı: " + args[0])
+			return
+		}
+		oldDir, newDir = runs[len(runs)-2], runs[len(runs)-1]
+	case 2:
+		oldDir, newDir = args[0], args[1]
+	default:
+		ui.PrintError("Kullanım: onionscraper diff <hedef_klasörü> | <eski_çalıştırma> <yeni_çalıştırma>")
+		return
+	}
+
+	rep, err := diff.Compare(oldDir, newDir)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Karşılaştırma başarısız: %v", err))
+		return
+	}
+
+	rep.WriteText(os.Stdout)
+	if err := rep.Save(); err != nil {
+		ui.PrintError(fmt.Sprintf("Karşılaştırma raporu kaydedilemedi: %v", err))
+		return
+	}
+	ui.PrintSuccess("Karşılaştırma raporu kaydedildi: " + filepath.Join(newDir, diff.ReportFile))
+}
+
+// saveToStore sonuçları SQLite geçmiş veritabanına yazar
+func saveToStore(info store.ScanInfo, results []scanner.ScanResult) {
+	path := config.GlobalSettings.Store.Path
+	db, err := store.Open(path)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Veritabanı açılamadı (%s): %v", path, err))
+		report.Log("ERROR", fmt.Sprintf("Veritabanı açılamadı (%s): %v", path, err))
+		return
+	}
+	defer db.Close()
+
+	scanID, err := db.SaveScan(info, results)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Sonuçlar veritabanına yazılamadı: %v", err))
+		report.Log("ERROR", fmt.Sprintf("Sonuçlar veritabanına yazılamadı: %v", err))
+		return
+	}
+	ui.PrintSuccess(fmt.Sprintf("Sonuçlar veritabanına kaydedildi: %s (Tarama #%d)", path, scanID))
+	report.Log("INFO", fmt.Sprintf("Sonuçlar veritabanına kaydedildi: %s (Tarama #%d)", path, scanID))
+}
+
 func analyzeOutput(dir string) ([]ui.FileInfo, string) {
 	var files []ui.FileInfo
 	var totalBytes int64